@@ -0,0 +1,92 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"tokenhub-api/internal/hdwallet"
+	"tokenhub-api/internal/signer"
+)
+
+const hdWalletUsage = `Usage:
+  tokenhub-api hdwallet new    -out FILE [-password-file FILE]
+  tokenhub-api hdwallet import -out FILE [-password-file FILE] [MNEMONICFILE]
+
+new generates a 24-word mnemonic and prints it once for offline backup;
+import encrypts a mnemonic read from MNEMONICFILE, or from stdin when omitted.
+The passphrase comes from -password-file or KEYSTORE_PASSWORD. Point
+HD_WALLET_KEYSTORE at the written file.
+`
+
+// runHDWallet implements the hdwallet subcommand and returns the exit code.
+func runHDWallet(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, hdWalletUsage)
+		return 2
+	}
+
+	fs := flag.NewFlagSet("hdwallet "+args[0], flag.ContinueOnError)
+	out := fs.String("out", "", "file to write the encrypted mnemonic to")
+	passwordFile := fs.String("password-file", "", "file holding the passphrase")
+	fs.Usage = func() { fmt.Fprint(os.Stderr, hdWalletUsage) }
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+	if *out == "" {
+		fs.Usage()
+		return 2
+	}
+
+	passphrase, err := signer.ReadPassphrase(*passwordFile, "KEYSTORE_PASSWORD")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if passphrase == "" {
+		fmt.Fprintln(os.Stderr, "refusing to use an empty passphrase")
+		return 1
+	}
+
+	var mnemonic string
+	switch args[0] {
+	case "new":
+		if mnemonic, err = hdwallet.NewMnemonic(); err != nil {
+			fmt.Fprintln(os.Stderr, "generating mnemonic:", err)
+			return 1
+		}
+	case "import":
+		line, err := readFirstLine(fs.Arg(0))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "reading mnemonic:", err)
+			return 1
+		}
+		mnemonic = strings.Join(strings.Fields(line), " ")
+	default:
+		fmt.Fprint(os.Stderr, hdWalletUsage)
+		return 2
+	}
+
+	wallet, err := hdwallet.NewWallet(mnemonic, "")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	first, err := wallet.Address(0)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if err := hdwallet.SaveMnemonic(*out, mnemonic, passphrase); err != nil {
+		fmt.Fprintln(os.Stderr, "writing mnemonic:", err)
+		return 1
+	}
+
+	if args[0] == "new" {
+		fmt.Fprintf(os.Stderr, "Mnemonic (write it down, it is not shown again):\n\n  %s\n\n", mnemonic)
+	}
+	fmt.Printf("Keystore: %s\nFirst address (%s): %s\n", *out, hdwallet.Path(0), first.Hex())
+	return 0
+}
@@ -0,0 +1,215 @@
+package gas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"tokenhub-api/internal/rpcpool"
+
+	"github.com/ethereum/go-ethereum/params"
+)
+
+type Profile string
+
+const (
+	ProfileSlow   Profile = "slow"
+	ProfileNormal Profile = "normal"
+	ProfileFast   Profile = "fast"
+)
+
+var Profiles = []Profile{ProfileSlow, ProfileNormal, ProfileFast}
+
+// profileParams tunes a profile: the priority fee percentile taken from recent
+// blocks, and how much base fee growth the fee cap absorbs, in percent.
+type profileParams struct {
+	rewardPercentile  float64
+	baseFeeMultiplier int64
+}
+
+var profileTable = map[Profile]profileParams{
+	ProfileSlow:   {rewardPercentile: 10, baseFeeMultiplier: 125},
+	ProfileNormal: {rewardPercentile: 50, baseFeeMultiplier: 200},
+	ProfileFast:   {rewardPercentile: 90, baseFeeMultiplier: 300},
+}
+
+// feeHistoryBlocks is how many recent blocks are sampled for priority fees.
+const feeHistoryBlocks = 10
+
+var ErrFeeCapTooLow = errors.New("max fee cap is below the current base fee")
+
+func ParseProfile(s string) (Profile, error) {
+	p := Profile(s)
+	if _, ok := profileTable[p]; !ok {
+		return "", fmt.Errorf("unknown gas profile %q (want slow, normal or fast)", s)
+	}
+	return p, nil
+}
+
+// Fees is an EIP-1559 fee quote.
+type Fees struct {
+	Profile   Profile  `json:"profile"`
+	BaseFee   *big.Int `json:"baseFee"`
+	GasTipCap *big.Int `json:"maxPriorityFeePerGas"`
+	GasFeeCap *big.Int `json:"maxFeePerGas"`
+}
+
+// Strategy prices every transaction at send time from the node's suggested
+// tip and recent base fees, instead of a gas price fixed at startup.
+type Strategy struct {
+	client *rpcpool.Pool
+
+	mu        sync.RWMutex
+	profile   Profile
+	maxFeeCap *big.Int
+}
+
+// NewStrategy returns a strategy using profile. maxFeeCap, in wei, bounds the
+// fee cap of every transaction; nil means unbounded.
+func NewStrategy(client *rpcpool.Pool, profile Profile, maxFeeCap *big.Int) *Strategy {
+	return &Strategy{client: client, profile: profile, maxFeeCap: maxFeeCap}
+}
+
+func (s *Strategy) Profile() Profile {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.profile
+}
+
+func (s *Strategy) SetProfile(p Profile) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.profile = p
+}
+
+func (s *Strategy) MaxFeeCap() *big.Int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.maxFeeCap
+}
+
+// Fees quotes the current profile.
+func (s *Strategy) Fees(ctx context.Context) (*Fees, error) {
+	return s.FeesFor(ctx, s.Profile())
+}
+
+// FeesFor quotes profile: the tip is the profile's percentile of recent
+// priority fees (never below the node's suggestion for normal and fast), and
+// the fee cap is the next block's base fee times the profile multiplier plus
+// the tip, limited by the configured maximum.
+func (s *Strategy) FeesFor(ctx context.Context, profile Profile) (*Fees, error) {
+	p, ok := profileTable[profile]
+	if !ok {
+		return nil, fmt.Errorf("unknown gas profile %q", profile)
+	}
+
+	suggestedTip, err := s.client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := s.client.FeeHistory(ctx, feeHistoryBlocks, nil, []float64{p.rewardPercentile})
+	if err != nil {
+		return nil, err
+	}
+	if len(history.BaseFee) == 0 {
+		return nil, errors.New("node returned no base fee history")
+	}
+	// The last entry is the base fee of the next, not yet mined, block.
+	baseFee := history.BaseFee[len(history.BaseFee)-1]
+
+	tip := averageReward(history.Reward)
+	if tip == nil || (profile != ProfileSlow && tip.Cmp(suggestedTip) < 0) {
+		tip = new(big.Int).Set(suggestedTip)
+	}
+
+	feeCap := new(big.Int).Mul(baseFee, big.NewInt(p.baseFeeMultiplier))
+	feeCap.Div(feeCap, big.NewInt(100))
+	feeCap.Add(feeCap, tip)
+
+	if maxFeeCap := s.MaxFeeCap(); maxFeeCap != nil && feeCap.Cmp(maxFeeCap) > 0 {
+		if maxFeeCap.Cmp(baseFee) < 0 {
+			return nil, fmt.Errorf("%w (%s < %s gwei)", ErrFeeCapTooLow, ToGwei(maxFeeCap), ToGwei(baseFee))
+		}
+		feeCap = new(big.Int).Set(maxFeeCap)
+		if tip.Cmp(new(big.Int).Sub(feeCap, baseFee)) > 0 {
+			tip = new(big.Int).Sub(feeCap, baseFee)
+		}
+	}
+
+	return &Fees{Profile: profile, BaseFee: baseFee, GasTipCap: tip, GasFeeCap: feeCap}, nil
+}
+
+// replacementBump is the minimum fee increase, in percent, nodes require before
+// accepting a transaction that replaces a pending one with the same nonce.
+const replacementBump = 10
+
+// ReplacementFees prices a transaction replacing a pending one that pays
+// tipCap and feeCap: the higher of the current quote and the original fees
+// bumped past the node's replacement threshold.
+func (s *Strategy) ReplacementFees(ctx context.Context, tipCap, feeCap *big.Int) (*Fees, error) {
+	current, err := s.Fees(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tip := maxBig(bump(tipCap), current.GasTipCap)
+	newFeeCap := maxBig(bump(feeCap), current.GasFeeCap)
+	if newFeeCap.Cmp(tip) < 0 {
+		newFeeCap = new(big.Int).Set(tip)
+	}
+
+	if maxFeeCap := s.MaxFeeCap(); maxFeeCap != nil && newFeeCap.Cmp(maxFeeCap) > 0 {
+		return nil, fmt.Errorf("replacement needs a fee cap of %s gwei, above the configured max of %s gwei", ToGwei(newFeeCap), ToGwei(maxFeeCap))
+	}
+
+	return &Fees{Profile: current.Profile, BaseFee: current.BaseFee, GasTipCap: tip, GasFeeCap: newFeeCap}, nil
+}
+
+// bump raises v by replacementBump percent plus one wei, rounding up.
+func bump(v *big.Int) *big.Int {
+	out := new(big.Int).Mul(v, big.NewInt(100+replacementBump))
+	out.Div(out, big.NewInt(100))
+	return out.Add(out, big.NewInt(1))
+}
+
+func maxBig(a, b *big.Int) *big.Int {
+	if a.Cmp(b) >= 0 {
+		return new(big.Int).Set(a)
+	}
+	return new(big.Int).Set(b)
+}
+
+func averageReward(rewards [][]*big.Int) *big.Int {
+	sum := new(big.Int)
+	count := int64(0)
+	for _, block := range rewards {
+		if len(block) == 0 || block[0] == nil {
+			continue
+		}
+		sum.Add(sum, block[0])
+		count++
+	}
+	if count == 0 {
+		return nil
+	}
+	return sum.Div(sum, big.NewInt(count))
+}
+
+// GweiToWei parses a decimal gwei amount such as "1.5".
+func GweiToWei(gwei string) (*big.Int, error) {
+	f, ok := new(big.Float).SetPrec(256).SetString(gwei)
+	if !ok || f.Sign() < 0 {
+		return nil, fmt.Errorf("invalid gwei amount %q", gwei)
+	}
+	wei, _ := f.Mul(f, big.NewFloat(params.GWei)).Int(nil)
+	return wei, nil
+}
+
+// ToGwei renders a wei amount in gwei.
+func ToGwei(wei *big.Int) string {
+	f := new(big.Float).Quo(new(big.Float).SetInt(wei), big.NewFloat(params.GWei))
+	return strings.TrimSuffix(strings.TrimRight(f.Text('f', 9), "0"), ".")
+}
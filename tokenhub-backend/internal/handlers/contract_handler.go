@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"tokenhub-api/internal/registry"
+	"tokenhub-api/internal/utils"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
+)
+
+// resolveContract turns a contract reference, an address or a registry alias,
+// into an address of the given standard. It writes the error response and
+// returns false when the reference cannot be used.
+func resolveContract(w http.ResponseWriter, contracts *registry.Registry, ref, standard string) (common.Address, bool) {
+	addr, err := contracts.Resolve(ref, standard)
+	if err == nil {
+		return addr, true
+	}
+
+	var wrongStandard *registry.WrongStandardError
+	switch {
+	case errors.Is(err, registry.ErrNotFound):
+		http.Error(w, fmt.Sprintf("%q is neither an address nor a registered alias", ref), http.StatusBadRequest)
+	case errors.As(err, &wrongStandard):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+	return common.Address{}, false
+}
+
+// HandleContracts lists the contracts deployed through TokenHub, optionally
+// filtered with ?standard=.
+func HandleContracts(contracts *registry.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		standard := r.URL.Query().Get("standard")
+		if standard != "" && !utils.IsValidStandard(standard) {
+			http.Error(w, "standard must be erc20, erc721 or erc1155", http.StatusBadRequest)
+			return
+		}
+
+		list, err := contracts.List(standard)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(list)
+	}
+}
+
+// HandleContract returns one registered contract by address or alias.
+func HandleContract(contracts *registry.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		contract, err := contracts.Get(mux.Vars(r)["address"])
+		if errors.Is(err, registry.ErrNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(contract)
+	}
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"tokenhub-api/internal/services"
+	"tokenhub-api/internal/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// HandleDeployEstimate estimates a deployment from the same parameters the
+// deploy routes take, passed as tokenName, tokenSymbol and (ERC20) initialSupply.
+func HandleDeployEstimate(svc services.EstimateService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		standard := mux.Vars(r)["standard"]
+		if !utils.IsValidStandard(standard) {
+			http.Error(w, "standard must be one of erc20, erc721, erc1155", http.StatusBadRequest)
+			return
+		}
+
+		tokenName := r.URL.Query().Get("tokenName")
+		tokenSymbol := r.URL.Query().Get("tokenSymbol")
+		if tokenName == "" || tokenSymbol == "" {
+			http.Error(w, "tokenName and tokenSymbol are required", http.StatusBadRequest)
+			return
+		}
+
+		var initialSupply *big.Int
+		if raw := r.URL.Query().Get("initialSupply"); raw != "" {
+			var ok bool
+			initialSupply, ok = new(big.Int).SetString(raw, 10)
+			if !ok || initialSupply.Sign() < 0 {
+				http.Error(w, "Invalid initialSupply", http.StatusBadRequest)
+				return
+			}
+		}
+
+		resp, err := svc.EstimateDeploy(standard, tokenName, tokenSymbol, initialSupply)
+		if errors.Is(err, services.ErrUnknownStandard) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"tokenhub-api/internal/gas"
+)
+
+type GasFeesResponse struct {
+	Profile   gas.Profile `json:"profile"`
+	MaxFeeCap string      `json:"maxFeeCapGwei,omitempty"`
+	Quotes    []gas.Fees  `json:"quotes"`
+}
+
+// HandleGasFees reports the active gas profile and a fee quote for every profile.
+func HandleGasFees(strategy *gas.Strategy) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		resp := GasFeesResponse{Profile: strategy.Profile()}
+		if maxFeeCap := strategy.MaxFeeCap(); maxFeeCap != nil {
+			resp.MaxFeeCap = gas.ToGwei(maxFeeCap)
+		}
+
+		for _, profile := range gas.Profiles {
+			fees, err := strategy.FeesFor(r.Context(), profile)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			resp.Quotes = append(resp.Quotes, *fees)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}
+}
+
+type SetGasProfileRequest struct {
+	Profile string `json:"profile"`
+}
+
+func SetGasProfileHandler(strategy *gas.Strategy) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req SetGasProfileRequest
+		if r.Body == nil {
+			http.Error(w, "Request body is empty", http.StatusBadRequest)
+			return
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request", http.StatusBadRequest)
+			return
+		}
+
+		profile, err := gas.ParseProfile(req.Profile)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		strategy.SetProfile(profile)
+
+		response := map[string]string{
+			"profile": string(profile),
+			"status":  "updated",
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"tokenhub-api/internal/indexer"
+	"tokenhub-api/internal/services"
+	"tokenhub-api/internal/utils"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	defaultHistoryPageSize = 50
+	maxHistoryPageSize     = 200
+)
+
+func HandleHistory(svc services.HistoryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		walletAddress := r.URL.Query().Get("walletAddress")
+		if !common.IsHexAddress(walletAddress) {
+			http.Error(w, "valid walletAddress is required", http.StatusBadRequest)
+			return
+		}
+
+		contractAddress := r.URL.Query().Get("contractAddress")
+		if !common.IsHexAddress(contractAddress) {
+			http.Error(w, "valid contractAddress is required", http.StatusBadRequest)
+			return
+		}
+
+		standard := r.URL.Query().Get("standard")
+		if standard != "" && !utils.IsValidStandard(standard) {
+			http.Error(w, "standard must be one of erc20, erc721, erc1155", http.StatusBadRequest)
+			return
+		}
+
+		page, err := intQueryParam(r, "page", 1)
+		if err != nil || page < 1 {
+			http.Error(w, "Invalid page", http.StatusBadRequest)
+			return
+		}
+
+		pageSize, err := intQueryParam(r, "pageSize", defaultHistoryPageSize)
+		if err != nil || pageSize < 1 || pageSize > maxHistoryPageSize {
+			http.Error(w, "pageSize must be between 1 and 200", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := svc.GetHistory(walletAddress, contractAddress, standard, page, pageSize)
+		if err != nil {
+			if errors.Is(err, indexer.ErrNotTracked) {
+				http.Error(w, err.Error(), http.StatusNotFound)
+				return
+			}
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}
+}
+
+// intQueryParam parses an optional integer query parameter.
+func intQueryParam(r *http.Request, name string, fallback int) (int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return fallback, nil
+	}
+	return strconv.Atoi(value)
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"tokenhub-api/internal/indexer"
+	"tokenhub-api/internal/utils"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func HandleIndexedContracts(idx *indexer.Indexer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		contracts, err := idx.Contracts()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if contracts == nil {
+			contracts = []indexer.TrackedContract{}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(contracts)
+	}
+}
+
+type TrackContractRequest struct {
+	ContractAddress string `json:"contractAddress"`
+	Standard        string `json:"standard"`
+	StartBlock      uint64 `json:"startBlock"`
+}
+
+func TrackContractHandler(idx *indexer.Indexer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req TrackContractRequest
+		if r.Body == nil {
+			http.Error(w, "Request body is empty", http.StatusBadRequest)
+			return
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request", http.StatusBadRequest)
+			return
+		}
+
+		if !common.IsHexAddress(req.ContractAddress) {
+			http.Error(w, "Invalid contract address", http.StatusBadRequest)
+			return
+		}
+
+		if !utils.IsValidStandard(req.Standard) {
+			http.Error(w, "standard must be one of erc20, erc721, erc1155", http.StatusBadRequest)
+			return
+		}
+
+		contractAddr := common.HexToAddress(req.ContractAddress)
+		if err := idx.Track(contractAddr, req.Standard, req.StartBlock); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		response := map[string]string{
+			"address": contractAddr.Hex(),
+			"status":  "tracked",
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}
+}
@@ -0,0 +1,25 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"tokenhub-api/internal/network"
+)
+
+type NetworkResponse struct {
+	network.Network
+	Default bool `json:"default"`
+}
+
+// HandleNetworks lists the configured networks; RPC URLs are never exposed.
+func HandleNetworks(cfg *network.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		resp := make([]NetworkResponse, 0, len(cfg.Networks))
+		for _, n := range cfg.Networks {
+			resp = append(resp, NetworkResponse{Network: n, Default: n.Name == cfg.Default})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}
+}
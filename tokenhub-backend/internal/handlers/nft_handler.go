@@ -2,14 +2,19 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"math/big"
 	"net/http"
+	"tokenhub-api/internal/registry"
 	"tokenhub-api/internal/services"
+	"tokenhub-api/internal/utils"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
-func HandleERC721Balance(svc services.NFTService) http.HandlerFunc {
+func HandleERC721Balance(svc services.NFTService, contracts *registry.Registry) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		walletAddress := r.URL.Query().Get("walletAddress")
 		if walletAddress == "" {
@@ -22,8 +27,12 @@ func HandleERC721Balance(svc services.NFTService) http.HandlerFunc {
 			http.Error(w, "contractAddress is required", http.StatusBadRequest)
 			return
 		}
+		contractAddr, ok := resolveContract(w, contracts, contractAddress, utils.StandardERC721)
+		if !ok {
+			return
+		}
 
-		resp, err := svc.GetERC721Details(walletAddress, contractAddress)
+		resp, err := svc.GetERC721Details(walletAddress, contractAddr.Hex())
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -33,7 +42,7 @@ func HandleERC721Balance(svc services.NFTService) http.HandlerFunc {
 	}
 }
 
-func HandleERC1155Balance(svc services.NFTService) http.HandlerFunc {
+func HandleERC1155Balance(svc services.NFTService, contracts *registry.Registry) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		walletAddress := r.URL.Query().Get("walletAddress")
 		if walletAddress == "" {
@@ -46,8 +55,18 @@ func HandleERC1155Balance(svc services.NFTService) http.HandlerFunc {
 			http.Error(w, "contractAddress is required", http.StatusBadRequest)
 			return
 		}
+		contractAddr, ok := resolveContract(w, contracts, contractAddress, utils.StandardERC1155)
+		if !ok {
+			return
+		}
 
-		resp, err := svc.GetERC1155Details(walletAddress, contractAddress)
+		idRange, err := parseTokenIDRange(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		resp, err := svc.GetERC1155Details(walletAddress, contractAddr.Hex(), idRange)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -57,9 +76,38 @@ func HandleERC1155Balance(svc services.NFTService) http.HandlerFunc {
 	}
 }
 
+// maxERC1155IDRange caps how many ids a single balance request may scan.
+const maxERC1155IDRange = 10000
+
+// parseTokenIDRange reads the optional inclusive fromId/toId query parameters.
+// It returns nil when neither is given so the service discovers ids from the indexer.
+func parseTokenIDRange(r *http.Request) (*services.TokenIDRange, error) {
+	fromParam := r.URL.Query().Get("fromId")
+	toParam := r.URL.Query().Get("toId")
+	if fromParam == "" && toParam == "" {
+		return nil, nil
+	}
+
+	from, ok1 := new(big.Int).SetString(fromParam, 10)
+	to, ok2 := new(big.Int).SetString(toParam, 10)
+	if !ok1 || !ok2 || from.Sign() < 0 || to.Cmp(from) < 0 {
+		return nil, errors.New("fromId and toId must both be set with 0 <= fromId <= toId")
+	}
+
+	span := new(big.Int).Sub(to, from)
+	if span.Cmp(big.NewInt(maxERC1155IDRange)) >= 0 {
+		return nil, fmt.Errorf("id range may span at most %d ids", maxERC1155IDRange)
+	}
+
+	return &services.TokenIDRange{From: from, To: to}, nil
+}
+
 type DeployERC721Request struct {
-	TokenName   string `json:"tokenName"`
-	TokenSymbol string `json:"tokenSymbol"`
+	TokenName   string   `json:"tokenName"`
+	TokenSymbol string   `json:"tokenSymbol"`
+	Signer      string   `json:"signer,omitempty"`
+	Alias       string   `json:"alias,omitempty"`
+	Labels      []string `json:"labels,omitempty"`
 }
 
 func DeployERC721Handler(svc services.NFTService) http.HandlerFunc {
@@ -75,9 +123,15 @@ func DeployERC721Handler(svc services.NFTService) http.HandlerFunc {
 			return
 		}
 
-		resp, err := svc.DeployERC721(req.TokenName, req.TokenSymbol)
+		if isDryRun(r) {
+			sim, err := svc.SimulateDeployERC721(req.Signer, req.TokenName, req.TokenSymbol)
+			writeSimulation(w, sim, err)
+			return
+		}
+
+		resp, err := svc.DeployERC721(req.Signer, req.TokenName, req.TokenSymbol, req.Alias, req.Labels)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			http.Error(w, err.Error(), writeErrorStatus(err))
 			return
 		}
 
@@ -87,8 +141,11 @@ func DeployERC721Handler(svc services.NFTService) http.HandlerFunc {
 }
 
 type DeployERC1155Request struct {
-	TokenName   string `json:"tokenName"`
-	TokenSymbol string `json:"tokenSymbol"`
+	TokenName   string   `json:"tokenName"`
+	TokenSymbol string   `json:"tokenSymbol"`
+	Signer      string   `json:"signer,omitempty"`
+	Alias       string   `json:"alias,omitempty"`
+	Labels      []string `json:"labels,omitempty"`
 }
 
 func DeployERC1155Handler(svc services.NFTService) http.HandlerFunc {
@@ -104,9 +161,15 @@ func DeployERC1155Handler(svc services.NFTService) http.HandlerFunc {
 			return
 		}
 
-		resp, err := svc.DeployERC1155(req.TokenName, req.TokenSymbol)
+		if isDryRun(r) {
+			sim, err := svc.SimulateDeployERC1155(req.Signer, req.TokenName, req.TokenSymbol)
+			writeSimulation(w, sim, err)
+			return
+		}
+
+		resp, err := svc.DeployERC1155(req.Signer, req.TokenName, req.TokenSymbol, req.Alias, req.Labels)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			http.Error(w, err.Error(), writeErrorStatus(err))
 			return
 		}
 
@@ -118,9 +181,10 @@ func DeployERC1155Handler(svc services.NFTService) http.HandlerFunc {
 type MintERC721Request struct {
 	ContractAddress string `json:"contractAddress"`
 	TokenURI        string `json:"tokenURI"`
+	Signer          string `json:"signer,omitempty"`
 }
 
-func MintERC721Handler(svc services.NFTService) http.HandlerFunc {
+func MintERC721Handler(svc services.NFTService, contracts *registry.Registry) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var req MintERC721Request
 		if r.Body == nil {
@@ -133,19 +197,24 @@ func MintERC721Handler(svc services.NFTService) http.HandlerFunc {
 			return
 		}
 
-		contractAddr := common.HexToAddress(req.ContractAddress)
-		txHash, err := svc.MintERC721(contractAddr, req.TokenURI)
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+		contractAddr, ok := resolveContract(w, contracts, req.ContractAddress, utils.StandardERC721)
+		if !ok {
 			return
 		}
 
-		response := map[string]string{
-			"transactionHash": txHash,
-			"status":          "minted",
+		if isDryRun(r) {
+			sim, err := svc.SimulateMintERC721(req.Signer, contractAddr, req.TokenURI)
+			writeSimulation(w, sim, err)
+			return
 		}
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(response)
+
+		txHash, err := svc.MintERC721(req.Signer, contractAddr, req.TokenURI)
+		if err != nil {
+			http.Error(w, err.Error(), writeErrorStatus(err))
+			return
+		}
+
+		writeSubmitted(w, r, txHash, "mint")
 	}
 }
 
@@ -154,9 +223,10 @@ type MintERC1155Request struct {
 	To              string `json:"to"`
 	Amount          string `json:"amount"`
 	TokenURI        string `json:"tokenURI"`
+	Signer          string `json:"signer,omitempty"`
 }
 
-func MintERC1155Handler(svc services.NFTService) http.HandlerFunc {
+func MintERC1155Handler(svc services.NFTService, contracts *registry.Registry) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var req MintERC1155Request
 		if r.Body == nil {
@@ -175,30 +245,220 @@ func MintERC1155Handler(svc services.NFTService) http.HandlerFunc {
 			return
 		}
 
+		contractAddr, ok := resolveContract(w, contracts, req.ContractAddress, utils.StandardERC1155)
+		if !ok {
+			return
+		}
+
+		if isDryRun(r) {
+			sim, err := svc.SimulateMintERC1155(req.Signer, contractAddr, req.To, amount, req.TokenURI)
+			writeSimulation(w, sim, err)
+			return
+		}
+
+		txHash, err := svc.MintERC1155(req.Signer, contractAddr, req.To, amount, req.TokenURI)
+		if err != nil {
+			http.Error(w, err.Error(), writeErrorStatus(err))
+			return
+		}
+
+		writeSubmitted(w, r, txHash, "mint")
+	}
+}
+
+type BurnERC721Request struct {
+	ContractAddress string `json:"contractAddress"`
+	TokenID         string `json:"tokenId"`
+	Signer          string `json:"signer,omitempty"`
+}
+
+func BurnERC721Handler(svc services.NFTService, contracts *registry.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req BurnERC721Request
+		if r.Body == nil {
+			http.Error(w, "Request body is empty", http.StatusBadRequest)
+			return
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request", http.StatusBadRequest)
+			return
+		}
+
+		tokenId, ok := new(big.Int).SetString(req.TokenID, 10)
+		if !ok {
+			http.Error(w, "Invalid token ID", http.StatusBadRequest)
+			return
+		}
+
+		contractAddr, ok := resolveContract(w, contracts, req.ContractAddress, utils.StandardERC721)
+		if !ok {
+			return
+		}
+
+		if isDryRun(r) {
+			sim, err := svc.SimulateBurnERC721(req.Signer, contractAddr, tokenId)
+			writeSimulation(w, sim, err)
+			return
+		}
+
+		txHash, err := svc.BurnERC721(req.Signer, contractAddr, tokenId)
+		if err != nil {
+			http.Error(w, err.Error(), writeErrorStatus(err))
+			return
+		}
+
+		writeSubmitted(w, r, txHash, "burn")
+	}
+}
+
+type BurnERC1155Request struct {
+	ContractAddress string `json:"contractAddress"`
+	TokenID         string `json:"tokenId"`
+	Amount          string `json:"amount"`
+	Signer          string `json:"signer,omitempty"`
+}
+
+func BurnERC1155Handler(svc services.NFTService, contracts *registry.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req BurnERC1155Request
+		if r.Body == nil {
+			http.Error(w, "Request body is empty", http.StatusBadRequest)
+			return
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request", http.StatusBadRequest)
+			return
+		}
+
+		tokenId, ok1 := new(big.Int).SetString(req.TokenID, 10)
+		amount, ok2 := new(big.Int).SetString(req.Amount, 10)
+		if !ok1 || !ok2 {
+			http.Error(w, "Invalid token ID or amount", http.StatusBadRequest)
+			return
+		}
+
+		contractAddr, ok := resolveContract(w, contracts, req.ContractAddress, utils.StandardERC1155)
+		if !ok {
+			return
+		}
+
+		if isDryRun(r) {
+			sim, err := svc.SimulateBurnERC1155(req.Signer, contractAddr, tokenId, amount)
+			writeSimulation(w, sim, err)
+			return
+		}
+
+		txHash, err := svc.BurnERC1155(req.Signer, contractAddr, tokenId, amount)
+		if err != nil {
+			http.Error(w, err.Error(), writeErrorStatus(err))
+			return
+		}
+
+		writeSubmitted(w, r, txHash, "burn")
+	}
+}
+
+type TransferERC721Request struct {
+	ContractAddress string `json:"contractAddress"`
+	From            string `json:"from,omitempty"`
+	To              string `json:"to"`
+	TokenID         string `json:"tokenId"`
+	Safe            bool   `json:"safe"`
+	Data            string `json:"data,omitempty"`
+}
+
+func TransferERC721Handler(svc services.NFTService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req TransferERC721Request
+		if r.Body == nil {
+			http.Error(w, "Request body is empty", http.StatusBadRequest)
+			return
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request", http.StatusBadRequest)
+			return
+		}
+
+		if !common.IsHexAddress(req.To) || (req.From != "" && !common.IsHexAddress(req.From)) {
+			http.Error(w, "Invalid from or to address", http.StatusBadRequest)
+			return
+		}
+
+		tokenId, ok := new(big.Int).SetString(req.TokenID, 10)
+		if !ok {
+			http.Error(w, "Invalid token ID", http.StatusBadRequest)
+			return
+		}
+
+		var data []byte
+		if req.Data != "" {
+			if !req.Safe {
+				http.Error(w, "data is only supported for safe transfers", http.StatusBadRequest)
+				return
+			}
+			decoded, err := decodeOptionalHex(req.Data)
+			if err != nil {
+				http.Error(w, "Invalid data", http.StatusBadRequest)
+				return
+			}
+			data = decoded
+		}
+
 		contractAddr := common.HexToAddress(req.ContractAddress)
-		txHash, err := svc.MintERC1155(contractAddr, req.To, amount, req.TokenURI)
+		txHash, err := svc.TransferERC721(contractAddr, req.From, req.To, tokenId, req.Safe, data)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 
-		response := map[string]string{
-			"transactionHash": txHash,
-			"status":          "minted",
+		writeSubmitted(w, r, txHash, "transfer")
+	}
+}
+
+func HandleERC721Approval(svc services.NFTService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		contractAddress := r.URL.Query().Get("contractAddress")
+		if contractAddress == "" {
+			http.Error(w, "contractAddress is required", http.StatusBadRequest)
+			return
+		}
+
+		tokenId, ok := new(big.Int).SetString(r.URL.Query().Get("tokenId"), 10)
+		if !ok {
+			http.Error(w, "Invalid token ID", http.StatusBadRequest)
+			return
+		}
+
+		owner := r.URL.Query().Get("owner")
+		operator := r.URL.Query().Get("operator")
+		if (owner != "" && !common.IsHexAddress(owner)) || (operator != "" && !common.IsHexAddress(operator)) {
+			http.Error(w, "Invalid owner or operator address", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := svc.GetERC721Approval(common.HexToAddress(contractAddress), tokenId, owner, operator)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
+
 		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(response)
+		json.NewEncoder(w).Encode(resp)
 	}
 }
 
-type BurnERC721Request struct {
+type ApproveERC721Request struct {
 	ContractAddress string `json:"contractAddress"`
+	To              string `json:"to"`
 	TokenID         string `json:"tokenId"`
 }
 
-func BurnERC721Handler(svc services.NFTService) http.HandlerFunc {
+func ApproveERC721Handler(svc services.NFTService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		var req BurnERC721Request
+		var req ApproveERC721Request
 		if r.Body == nil {
 			http.Error(w, "Request body is empty", http.StatusBadRequest)
 			return
@@ -209,6 +469,11 @@ func BurnERC721Handler(svc services.NFTService) http.HandlerFunc {
 			return
 		}
 
+		if !common.IsHexAddress(req.To) {
+			http.Error(w, "Invalid approved address", http.StatusBadRequest)
+			return
+		}
+
 		tokenId, ok := new(big.Int).SetString(req.TokenID, 10)
 		if !ok {
 			http.Error(w, "Invalid token ID", http.StatusBadRequest)
@@ -216,30 +481,63 @@ func BurnERC721Handler(svc services.NFTService) http.HandlerFunc {
 		}
 
 		contractAddr := common.HexToAddress(req.ContractAddress)
-		txHash, err := svc.BurnERC721(contractAddr, tokenId)
+		txHash, err := svc.ApproveERC721(contractAddr, req.To, tokenId)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 
-		response := map[string]string{
-			"transactionHash": txHash,
-			"status":          "burned",
+		writeSubmitted(w, r, txHash, "approve")
+	}
+}
+
+type SetApprovalForAllERC721Request struct {
+	ContractAddress string `json:"contractAddress"`
+	Operator        string `json:"operator"`
+	Approved        bool   `json:"approved"`
+}
+
+func SetApprovalForAllERC721Handler(svc services.NFTService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req SetApprovalForAllERC721Request
+		if r.Body == nil {
+			http.Error(w, "Request body is empty", http.StatusBadRequest)
+			return
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request", http.StatusBadRequest)
+			return
 		}
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(response)
+
+		if !common.IsHexAddress(req.Operator) {
+			http.Error(w, "Invalid operator address", http.StatusBadRequest)
+			return
+		}
+
+		contractAddr := common.HexToAddress(req.ContractAddress)
+		txHash, err := svc.SetApprovalForAllERC721(contractAddr, req.Operator, req.Approved)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		writeSubmitted(w, r, txHash, "setApprovalForAll")
 	}
 }
 
-type BurnERC1155Request struct {
+type TransferERC1155Request struct {
 	ContractAddress string `json:"contractAddress"`
+	From            string `json:"from,omitempty"`
+	To              string `json:"to"`
 	TokenID         string `json:"tokenId"`
 	Amount          string `json:"amount"`
+	Data            string `json:"data,omitempty"`
 }
 
-func BurnERC1155Handler(svc services.NFTService) http.HandlerFunc {
+func TransferERC1155Handler(svc services.NFTService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		var req BurnERC1155Request
+		var req TransferERC1155Request
 		if r.Body == nil {
 			http.Error(w, "Request body is empty", http.StatusBadRequest)
 			return
@@ -250,6 +548,11 @@ func BurnERC1155Handler(svc services.NFTService) http.HandlerFunc {
 			return
 		}
 
+		if !common.IsHexAddress(req.To) || (req.From != "" && !common.IsHexAddress(req.From)) {
+			http.Error(w, "Invalid from or to address", http.StatusBadRequest)
+			return
+		}
+
 		tokenId, ok1 := new(big.Int).SetString(req.TokenID, 10)
 		amount, ok2 := new(big.Int).SetString(req.Amount, 10)
 		if !ok1 || !ok2 {
@@ -257,18 +560,89 @@ func BurnERC1155Handler(svc services.NFTService) http.HandlerFunc {
 			return
 		}
 
+		data, err := decodeOptionalHex(req.Data)
+		if err != nil {
+			http.Error(w, "Invalid data", http.StatusBadRequest)
+			return
+		}
+
 		contractAddr := common.HexToAddress(req.ContractAddress)
-		txHash, err := svc.BurnERC1155(contractAddr, tokenId, amount)
+		txHash, err := svc.TransferERC1155(contractAddr, req.From, req.To, tokenId, amount, data)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 
-		response := map[string]string{
-			"transactionHash": txHash,
-			"status":          "burned",
+		writeSubmitted(w, r, txHash, "transfer")
+	}
+}
+
+type BatchTransferERC1155Request struct {
+	ContractAddress string   `json:"contractAddress"`
+	From            string   `json:"from,omitempty"`
+	To              string   `json:"to"`
+	TokenIDs        []string `json:"tokenIds"`
+	Amounts         []string `json:"amounts"`
+	Data            string   `json:"data,omitempty"`
+}
+
+func BatchTransferERC1155Handler(svc services.NFTService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req BatchTransferERC1155Request
+		if r.Body == nil {
+			http.Error(w, "Request body is empty", http.StatusBadRequest)
+			return
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request", http.StatusBadRequest)
+			return
+		}
+
+		if !common.IsHexAddress(req.To) || (req.From != "" && !common.IsHexAddress(req.From)) {
+			http.Error(w, "Invalid from or to address", http.StatusBadRequest)
+			return
+		}
+
+		if len(req.TokenIDs) == 0 || len(req.TokenIDs) != len(req.Amounts) {
+			http.Error(w, "tokenIds and amounts must be non-empty and of equal length", http.StatusBadRequest)
+			return
 		}
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(response)
+
+		tokenIds := make([]*big.Int, len(req.TokenIDs))
+		amounts := make([]*big.Int, len(req.Amounts))
+		for i := range req.TokenIDs {
+			tokenId, ok1 := new(big.Int).SetString(req.TokenIDs[i], 10)
+			amount, ok2 := new(big.Int).SetString(req.Amounts[i], 10)
+			if !ok1 || !ok2 {
+				http.Error(w, "Invalid token ID or amount", http.StatusBadRequest)
+				return
+			}
+			tokenIds[i] = tokenId
+			amounts[i] = amount
+		}
+
+		data, err := decodeOptionalHex(req.Data)
+		if err != nil {
+			http.Error(w, "Invalid data", http.StatusBadRequest)
+			return
+		}
+
+		contractAddr := common.HexToAddress(req.ContractAddress)
+		txHash, err := svc.BatchTransferERC1155(contractAddr, req.From, req.To, tokenIds, amounts, data)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		writeSubmitted(w, r, txHash, "batchTransfer")
+	}
+}
+
+// decodeOptionalHex decodes a 0x-prefixed hex string, treating "" as no data.
+func decodeOptionalHex(s string) ([]byte, error) {
+	if s == "" {
+		return nil, nil
 	}
+	return hexutil.Decode(s)
 }
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"tokenhub-api/internal/services"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
+)
+
+// contractAddressFromPath validates the {address} route variable.
+func contractAddressFromPath(w http.ResponseWriter, r *http.Request) (common.Address, bool) {
+	address := mux.Vars(r)["address"]
+	if !common.IsHexAddress(address) {
+		http.Error(w, "Invalid contract address", http.StatusBadRequest)
+		return common.Address{}, false
+	}
+	return common.HexToAddress(address), true
+}
+
+func writeOwnershipError(w http.ResponseWriter, err error) {
+	if errors.Is(err, services.ErrNoContract) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, services.ErrOwnershipNotTransferable) {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
+
+func HandleContractOwner(svc services.OwnershipService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		contractAddr, ok := contractAddressFromPath(w, r)
+		if !ok {
+			return
+		}
+
+		resp, err := svc.GetOwner(contractAddr)
+		if err != nil {
+			writeOwnershipError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}
+}
+
+type TransferOwnershipRequest struct {
+	NewOwner string `json:"newOwner"`
+}
+
+func TransferOwnershipHandler(svc services.OwnershipService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		contractAddr, ok := contractAddressFromPath(w, r)
+		if !ok {
+			return
+		}
+
+		var req TransferOwnershipRequest
+		if r.Body == nil {
+			http.Error(w, "Request body is empty", http.StatusBadRequest)
+			return
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request", http.StatusBadRequest)
+			return
+		}
+
+		if !common.IsHexAddress(req.NewOwner) || common.HexToAddress(req.NewOwner) == (common.Address{}) {
+			http.Error(w, "Invalid new owner address", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := svc.TransferOwnership(contractAddr, req.NewOwner)
+		if err != nil {
+			writeOwnershipError(w, err)
+			return
+		}
+
+		writeSubmitted(w, r, resp.TransactionHash, "transferOwnership")
+	}
+}
+
+type RenounceOwnershipRequest struct {
+	// Confirm must be true; renouncing leaves the contract without an owner forever.
+	Confirm bool `json:"confirm"`
+}
+
+func RenounceOwnershipHandler(svc services.OwnershipService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		contractAddr, ok := contractAddressFromPath(w, r)
+		if !ok {
+			return
+		}
+
+		var req RenounceOwnershipRequest
+		if r.Body == nil {
+			http.Error(w, "Request body is empty", http.StatusBadRequest)
+			return
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request", http.StatusBadRequest)
+			return
+		}
+
+		if !req.Confirm {
+			http.Error(w, "confirm must be true to renounce ownership", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := svc.RenounceOwnership(contractAddr)
+		if err != nil {
+			writeOwnershipError(w, err)
+			return
+		}
+
+		writeSubmitted(w, r, resp.TransactionHash, "renounceOwnership")
+	}
+}
@@ -0,0 +1,15 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"tokenhub-api/internal/rpcpool"
+)
+
+// HandleRPCStatus reports the health of each RPC endpoint of the network.
+func HandleRPCStatus(pool *rpcpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(pool.Status())
+	}
+}
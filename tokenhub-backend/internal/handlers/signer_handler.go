@@ -0,0 +1,15 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"tokenhub-api/internal/signer"
+)
+
+// HandleSigners lists the registered signers by name and address; keys are never exposed.
+func HandleSigners(signers *signer.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(signers.List())
+	}
+}
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"tokenhub-api/internal/registry"
+	"tokenhub-api/internal/services"
+	"tokenhub-api/internal/signer"
+)
+
+// isDryRun reports whether the request asked for ?dryRun=true, in which case
+// the write is simulated and nothing is broadcast.
+func isDryRun(r *http.Request) bool {
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dryRun"))
+	return dryRun
+}
+
+// writeSimulation responds with a dry run result. A simulated revert is a
+// successful dry run and is reported in the body, not the status code.
+func writeSimulation(w http.ResponseWriter, resp *services.SimulationResponse, err error) {
+	if err != nil {
+		http.Error(w, err.Error(), writeErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// writeErrorStatus maps an error from a write that names its signer to an
+// HTTP status: an unknown signer or a bad alias is the caller's mistake.
+func writeErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, signer.ErrUnknownSigner), errors.Is(err, registry.ErrInvalidAlias):
+		return http.StatusBadRequest
+	case errors.Is(err, registry.ErrAliasTaken):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
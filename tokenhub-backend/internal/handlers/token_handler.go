@@ -4,12 +4,14 @@ import (
 	"encoding/json"
 	"math/big"
 	"net/http"
+	"tokenhub-api/internal/registry"
 	"tokenhub-api/internal/services"
+	"tokenhub-api/internal/utils"
 
 	"github.com/ethereum/go-ethereum/common"
 )
 
-func HandleERC20Balance(svc services.TokenService) http.HandlerFunc {
+func HandleERC20Balance(svc services.TokenService, contracts *registry.Registry) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 
 		walletAddress := r.URL.Query().Get("walletAddress")
@@ -23,8 +25,12 @@ func HandleERC20Balance(svc services.TokenService) http.HandlerFunc {
 			http.Error(w, "contractAddress is required", http.StatusBadRequest)
 			return
 		}
+		contractAddr, ok := resolveContract(w, contracts, contractAddress, utils.StandardERC20)
+		if !ok {
+			return
+		}
 
-		resp, err := svc.GetERC20Details(walletAddress, contractAddress)
+		resp, err := svc.GetERC20Details(walletAddress, contractAddr.Hex())
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -38,6 +44,12 @@ type DeployERC20Request struct {
 	TokenName     string `json:"tokenName"`
 	TokenSymbol   string `json:"tokenSymbol"`
 	InitialSupply string `json:"initialSupply"`
+	// Signer names the registered key to send from; empty means the default signer.
+	Signer string `json:"signer,omitempty"`
+	// Alias registers the contract under a name that mint, burn and balance
+	// requests accept in place of its address.
+	Alias  string   `json:"alias,omitempty"`
+	Labels []string `json:"labels,omitempty"`
 }
 
 func DeployERC20Handler(svc services.TokenService) http.HandlerFunc {
@@ -59,9 +71,15 @@ func DeployERC20Handler(svc services.TokenService) http.HandlerFunc {
 			return
 		}
 
-		resp, err := svc.DeployERC20(req.TokenName, req.TokenSymbol, rawAmount)
+		if isDryRun(r) {
+			sim, err := svc.SimulateDeployERC20(req.Signer, req.TokenName, req.TokenSymbol, rawAmount)
+			writeSimulation(w, sim, err)
+			return
+		}
+
+		resp, err := svc.DeployERC20(req.Signer, req.TokenName, req.TokenSymbol, rawAmount, req.Alias, req.Labels)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			http.Error(w, err.Error(), writeErrorStatus(err))
 			return
 		}
 
@@ -74,9 +92,10 @@ type MintRequest struct {
 	ContractAddress string `json:"contractAddress"`
 	To              string `json:"to"`
 	Amount          string `json:"amount"`
+	Signer          string `json:"signer,omitempty"`
 }
 
-func MintERC20Handler(svc services.TokenService) http.HandlerFunc {
+func MintERC20Handler(svc services.TokenService, contracts *registry.Registry) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var req MintRequest
 		if r.Body == nil {
@@ -95,28 +114,34 @@ func MintERC20Handler(svc services.TokenService) http.HandlerFunc {
 			return
 		}
 
-		contractAddr := common.HexToAddress(req.ContractAddress)
-		txHash, err := svc.MintERC20(contractAddr, req.To, amount)
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+		contractAddr, ok := resolveContract(w, contracts, req.ContractAddress, utils.StandardERC20)
+		if !ok {
 			return
 		}
 
-		response := map[string]string{
-			"transactionHash": txHash,
-			"status":          "minted",
+		if isDryRun(r) {
+			sim, err := svc.SimulateMintERC20(req.Signer, contractAddr, req.To, amount)
+			writeSimulation(w, sim, err)
+			return
 		}
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(response)
+
+		txHash, err := svc.MintERC20(req.Signer, contractAddr, req.To, amount)
+		if err != nil {
+			http.Error(w, err.Error(), writeErrorStatus(err))
+			return
+		}
+
+		writeSubmitted(w, r, txHash, "mint")
 	}
 }
 
 type BurnERC20Request struct {
 	ContractAddress string `json:"contractAddress"`
 	Amount          string `json:"amount"`
+	Signer          string `json:"signer,omitempty"`
 }
 
-func BurnERC20Handler(svc services.TokenService) http.HandlerFunc {
+func BurnERC20Handler(svc services.TokenService, contracts *registry.Registry) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var req BurnERC20Request
 		if r.Body == nil {
@@ -135,18 +160,315 @@ func BurnERC20Handler(svc services.TokenService) http.HandlerFunc {
 			return
 		}
 
+		contractAddr, ok := resolveContract(w, contracts, req.ContractAddress, utils.StandardERC20)
+		if !ok {
+			return
+		}
+
+		if isDryRun(r) {
+			sim, err := svc.SimulateBurnERC20(req.Signer, contractAddr, amount)
+			writeSimulation(w, sim, err)
+			return
+		}
+
+		txHash, err := svc.BurnERC20(req.Signer, contractAddr, amount)
+		if err != nil {
+			http.Error(w, err.Error(), writeErrorStatus(err))
+			return
+		}
+
+		writeSubmitted(w, r, txHash, "burn")
+	}
+}
+
+type TransferERC20Request struct {
+	ContractAddress string `json:"contractAddress"`
+	To              string `json:"to"`
+	Amount          string `json:"amount"`
+}
+
+func TransferERC20Handler(svc services.TokenService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req TransferERC20Request
+		if r.Body == nil {
+			http.Error(w, "Request body is empty", http.StatusBadRequest)
+			return
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request", http.StatusBadRequest)
+			return
+		}
+
+		if !common.IsHexAddress(req.To) {
+			http.Error(w, "Invalid recipient address", http.StatusBadRequest)
+			return
+		}
+
+		amount, ok := new(big.Int).SetString(req.Amount, 10)
+		if !ok || amount.Sign() < 0 {
+			http.Error(w, "Invalid amount", http.StatusBadRequest)
+			return
+		}
+
 		contractAddr := common.HexToAddress(req.ContractAddress)
-		txHash, err := svc.BurnERC20(contractAddr, amount)
+		txHash, err := svc.TransferERC20(contractAddr, req.To, amount)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 
-		response := map[string]string{
-			"transactionHash": txHash,
-			"status":          "burned",
+		writeSubmitted(w, r, txHash, "transfer")
+	}
+}
+
+type TransferFromERC20Request struct {
+	ContractAddress string `json:"contractAddress"`
+	From            string `json:"from"`
+	To              string `json:"to"`
+	Amount          string `json:"amount"`
+}
+
+func TransferFromERC20Handler(svc services.TokenService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req TransferFromERC20Request
+		if r.Body == nil {
+			http.Error(w, "Request body is empty", http.StatusBadRequest)
+			return
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request", http.StatusBadRequest)
+			return
+		}
+
+		if !common.IsHexAddress(req.From) || !common.IsHexAddress(req.To) {
+			http.Error(w, "Invalid from or to address", http.StatusBadRequest)
+			return
+		}
+
+		amount, ok := new(big.Int).SetString(req.Amount, 10)
+		if !ok || amount.Sign() < 0 {
+			http.Error(w, "Invalid amount", http.StatusBadRequest)
+			return
+		}
+
+		contractAddr := common.HexToAddress(req.ContractAddress)
+		txHash, err := svc.TransferFromERC20(contractAddr, req.From, req.To, amount)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		writeSubmitted(w, r, txHash, "transferFrom")
+	}
+}
+
+func HandleERC20Allowance(svc services.TokenService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		owner := r.URL.Query().Get("owner")
+		if owner == "" {
+			http.Error(w, "owner is required", http.StatusBadRequest)
+			return
 		}
+
+		spender := r.URL.Query().Get("spender")
+		if spender == "" {
+			http.Error(w, "spender is required", http.StatusBadRequest)
+			return
+		}
+
+		contractAddress := r.URL.Query().Get("contractAddress")
+		if contractAddress == "" {
+			http.Error(w, "contractAddress is required", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := svc.GetERC20Allowance(owner, spender, contractAddress)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
 		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(response)
+		json.NewEncoder(w).Encode(resp)
+	}
+}
+
+type ApproveERC20Request struct {
+	ContractAddress string `json:"contractAddress"`
+	Spender         string `json:"spender"`
+	Amount          string `json:"amount"`
+}
+
+func ApproveERC20Handler(svc services.TokenService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req ApproveERC20Request
+		if r.Body == nil {
+			http.Error(w, "Request body is empty", http.StatusBadRequest)
+			return
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request", http.StatusBadRequest)
+			return
+		}
+
+		if !common.IsHexAddress(req.Spender) {
+			http.Error(w, "Invalid spender address", http.StatusBadRequest)
+			return
+		}
+
+		amount, ok := new(big.Int).SetString(req.Amount, 10)
+		if !ok || amount.Sign() < 0 {
+			http.Error(w, "Invalid amount", http.StatusBadRequest)
+			return
+		}
+
+		contractAddr := common.HexToAddress(req.ContractAddress)
+		resp, err := svc.ApproveERC20(contractAddr, req.Spender, amount)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		writeSubmitted(w, r, resp.TransactionHash, "approve")
+	}
+}
+
+type RevokeERC20Request struct {
+	ContractAddress string `json:"contractAddress"`
+	Spender         string `json:"spender"`
+}
+
+func RevokeERC20Handler(svc services.TokenService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req RevokeERC20Request
+		if r.Body == nil {
+			http.Error(w, "Request body is empty", http.StatusBadRequest)
+			return
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request", http.StatusBadRequest)
+			return
+		}
+
+		if !common.IsHexAddress(req.Spender) {
+			http.Error(w, "Invalid spender address", http.StatusBadRequest)
+			return
+		}
+
+		contractAddr := common.HexToAddress(req.ContractAddress)
+		resp, err := svc.RevokeERC20Approval(contractAddr, req.Spender)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		writeSubmitted(w, r, resp.TransactionHash, "revoke")
+	}
+}
+
+type PauseERC20Request struct {
+	ContractAddress string `json:"contractAddress"`
+}
+
+func PauseERC20Handler(svc services.TokenService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req PauseERC20Request
+		if r.Body == nil {
+			http.Error(w, "Request body is empty", http.StatusBadRequest)
+			return
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request", http.StatusBadRequest)
+			return
+		}
+
+		if !common.IsHexAddress(req.ContractAddress) {
+			http.Error(w, "Invalid contract address", http.StatusBadRequest)
+			return
+		}
+
+		contractAddr := common.HexToAddress(req.ContractAddress)
+		txHash, err := svc.PauseERC20(contractAddr)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		writeSubmitted(w, r, txHash, "pause")
+	}
+}
+
+func UnpauseERC20Handler(svc services.TokenService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req PauseERC20Request
+		if r.Body == nil {
+			http.Error(w, "Request body is empty", http.StatusBadRequest)
+			return
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request", http.StatusBadRequest)
+			return
+		}
+
+		if !common.IsHexAddress(req.ContractAddress) {
+			http.Error(w, "Invalid contract address", http.StatusBadRequest)
+			return
+		}
+
+		contractAddr := common.HexToAddress(req.ContractAddress)
+		txHash, err := svc.UnpauseERC20(contractAddr)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		writeSubmitted(w, r, txHash, "unpause")
+	}
+}
+
+type RescueERC20Request struct {
+	ContractAddress string `json:"contractAddress"`
+	Token           string `json:"token"`
+	Amount          string `json:"amount"`
+}
+
+func RescueERC20Handler(svc services.TokenService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req RescueERC20Request
+		if r.Body == nil {
+			http.Error(w, "Request body is empty", http.StatusBadRequest)
+			return
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request", http.StatusBadRequest)
+			return
+		}
+
+		if !common.IsHexAddress(req.ContractAddress) || !common.IsHexAddress(req.Token) {
+			http.Error(w, "Invalid contract or token address", http.StatusBadRequest)
+			return
+		}
+
+		amount, ok := new(big.Int).SetString(req.Amount, 10)
+		if !ok || amount.Sign() <= 0 {
+			http.Error(w, "Invalid amount", http.StatusBadRequest)
+			return
+		}
+
+		contractAddr := common.HexToAddress(req.ContractAddress)
+		resp, err := svc.RescueERC20Funds(contractAddr, req.Token, amount)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		writeSubmitted(w, r, resp.TransactionHash, "rescue")
 	}
 }
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"tokenhub-api/internal/network"
+	"tokenhub-api/internal/services"
+	"tokenhub-api/internal/txtracker"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+)
+
+// txHashFromPath validates the {hash} route variable.
+func txHashFromPath(w http.ResponseWriter, r *http.Request) (common.Hash, bool) {
+	hash := mux.Vars(r)["hash"]
+	raw, err := hexutil.Decode(hash)
+	if err != nil || len(raw) != common.HashLength {
+		http.Error(w, "Invalid transaction hash", http.StatusBadRequest)
+		return common.Hash{}, false
+	}
+	return common.BytesToHash(raw), true
+}
+
+// SubmittedResponse acknowledges a broadcast write. Whether it succeeds is
+// only known once it is mined; StatusURL serves the tracked outcome.
+type SubmittedResponse struct {
+	TransactionHash string           `json:"transactionHash"`
+	Action          string           `json:"action"`
+	Status          txtracker.Status `json:"status"`
+	StatusURL       string           `json:"statusUrl"`
+}
+
+// writeSubmitted responds to a write that was broadcast but not yet mined.
+func writeSubmitted(w http.ResponseWriter, r *http.Request, txHash, action string) {
+	statusURL := "/api/tx/" + txHash
+	if name := r.URL.Query().Get("network"); name != "" {
+		statusURL += "?network=" + url.QueryEscape(name)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(SubmittedResponse{
+		TransactionHash: txHash,
+		Action:          action,
+		Status:          txtracker.StatusPending,
+		StatusURL:       statusURL,
+	})
+}
+
+type TransactionStatusResponse struct {
+	txtracker.Record
+	Network     string `json:"network"`
+	ExplorerURL string `json:"explorerUrl,omitempty"`
+}
+
+func HandleTransactionStatus(tracker *txtracker.Tracker, net network.Network) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		hash, ok := txHashFromPath(w, r)
+		if !ok {
+			return
+		}
+
+		record, err := tracker.Get(r.Context(), hash)
+		if errors.Is(err, txtracker.ErrNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(TransactionStatusResponse{
+			Record:      *record,
+			Network:     net.Name,
+			ExplorerURL: net.TxURL(record.Hash),
+		})
+	}
+}
+
+func SpeedUpTransactionHandler(txSvc services.TransactionService) http.HandlerFunc {
+	return replacementHandler(txSvc.SpeedUpTransaction, "speedup")
+}
+
+func CancelTransactionHandler(txSvc services.TransactionService) http.HandlerFunc {
+	return replacementHandler(txSvc.CancelTransaction, "cancel")
+}
+
+func replacementHandler(replace func(hash common.Hash) (*services.ReplacementResponse, error), action string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		hash, ok := txHashFromPath(w, r)
+		if !ok {
+			return
+		}
+
+		result, err := replace(hash)
+		switch {
+		case errors.Is(err, txtracker.ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		case errors.Is(err, txtracker.ErrNotPending), errors.Is(err, services.ErrNotOwnTransaction):
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		writeSubmitted(w, r, result.TransactionHash, action)
+	}
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"tokenhub-api/internal/services"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
+)
+
+type CreateWalletRequest struct {
+	Label string `json:"label"`
+}
+
+// CreateWalletHandler allocates the next HD wallet index. The body is optional.
+func CreateWalletHandler(svc services.WalletService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req CreateWalletRequest
+		if r.Body != nil {
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+				http.Error(w, "Invalid request", http.StatusBadRequest)
+				return
+			}
+		}
+
+		account, err := svc.CreateWallet(req.Label)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(account)
+	}
+}
+
+// HandleWallet returns a deposit address with its ETH balance and the token
+// balances for the comma-separated contracts in the erc20, erc721 and erc1155
+// query parameters.
+func HandleWallet(svc services.WalletService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 31)
+		if err != nil {
+			http.Error(w, "Invalid wallet id", http.StatusBadRequest)
+			return
+		}
+
+		var contracts services.WalletContracts
+		for _, param := range []struct {
+			name string
+			dst  *[]string
+		}{
+			{"erc20", &contracts.ERC20},
+			{"erc721", &contracts.ERC721},
+			{"erc1155", &contracts.ERC1155},
+		} {
+			list, ok := addressListParam(r, param.name)
+			if !ok {
+				http.Error(w, "Invalid "+param.name+" contract address", http.StatusBadRequest)
+				return
+			}
+			*param.dst = list
+		}
+
+		resp, err := svc.GetWallet(uint32(id), contracts)
+		if errors.Is(err, services.ErrWalletNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}
+}
+
+// addressListParam splits a comma-separated list of addresses; ok is false
+// if any entry is not an address.
+func addressListParam(r *http.Request, name string) ([]string, bool) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return nil, true
+	}
+
+	var list []string
+	for _, addr := range strings.Split(raw, ",") {
+		addr = strings.TrimSpace(addr)
+		if !common.IsHexAddress(addr) {
+			return nil, false
+		}
+		list = append(list, addr)
+	}
+	return list, true
+}
@@ -0,0 +1,135 @@
+package hdwallet
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// hardened marks a hardened BIP-32 child index.
+const hardened = 1 << 31
+
+// BasePath is the BIP-44 account path for Ethereum; addresses are its
+// external chain children, m/44'/60'/0'/0/<index>.
+var BasePath = []uint32{44 + hardened, 60 + hardened, 0 + hardened, 0}
+
+var errInvalidChild = errors.New("derived key is invalid, use the next index")
+
+// extendedKey is a BIP-32 private key with its chain code.
+type extendedKey struct {
+	key       *big.Int
+	chainCode []byte
+}
+
+// Wallet derives Ethereum accounts from a BIP-39 seed.
+type Wallet struct {
+	// account is the extended key at BasePath, so each address costs one derivation.
+	account *extendedKey
+}
+
+// NewWallet validates mnemonic and derives the BIP-44 account key from its
+// seed. passphrase is the optional BIP-39 passphrase, not the keystore one.
+func NewWallet(mnemonic, passphrase string) (*Wallet, error) {
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, errors.New("invalid mnemonic")
+	}
+	seed := bip39.NewSeed(mnemonic, passphrase)
+
+	key, err := masterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	for _, index := range BasePath {
+		if key, err = key.child(index); err != nil {
+			return nil, err
+		}
+	}
+	return &Wallet{account: key}, nil
+}
+
+// NewMnemonic returns a fresh 24-word mnemonic.
+func NewMnemonic() (string, error) {
+	entropy, err := bip39.NewEntropy(256)
+	if err != nil {
+		return "", err
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// Path renders the derivation path of index.
+func Path(index uint32) string {
+	return fmt.Sprintf("m/44'/60'/0'/0/%d", index)
+}
+
+// PrivateKey derives the key at m/44'/60'/0'/0/index.
+func (w *Wallet) PrivateKey(index uint32) (*ecdsa.PrivateKey, error) {
+	if index >= hardened {
+		return nil, fmt.Errorf("index %d out of range", index)
+	}
+	child, err := w.account.child(index)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.ToECDSA(math.PaddedBigBytes(child.key, 32))
+}
+
+// Address derives the address at m/44'/60'/0'/0/index.
+func (w *Wallet) Address(index uint32) (common.Address, error) {
+	key, err := w.PrivateKey(index)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(key.PublicKey), nil
+}
+
+func masterKey(seed []byte) (*extendedKey, error) {
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	key := new(big.Int).SetBytes(sum[:32])
+	if key.Sign() == 0 || key.Cmp(crypto.S256().Params().N) >= 0 {
+		return nil, errors.New("seed produces an invalid master key")
+	}
+	return &extendedKey{key: key, chainCode: sum[32:]}, nil
+}
+
+// child implements BIP-32 private parent to private child derivation.
+func (k *extendedKey) child(index uint32) (*extendedKey, error) {
+	var data []byte
+	if index >= hardened {
+		data = append([]byte{0}, math.PaddedBigBytes(k.key, 32)...)
+	} else {
+		priv, err := crypto.ToECDSA(math.PaddedBigBytes(k.key, 32))
+		if err != nil {
+			return nil, err
+		}
+		data = crypto.CompressPubkey(&priv.PublicKey)
+	}
+	data = binary.BigEndian.AppendUint32(data, index)
+
+	mac := hmac.New(sha512.New, k.chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	n := crypto.S256().Params().N
+	il := new(big.Int).SetBytes(sum[:32])
+	if il.Cmp(n) >= 0 {
+		return nil, errInvalidChild
+	}
+	key := il.Add(il, k.key)
+	key.Mod(key, n)
+	if key.Sign() == 0 {
+		return nil, errInvalidChild
+	}
+	return &extendedKey{key: key, chainCode: sum[32:]}, nil
+}
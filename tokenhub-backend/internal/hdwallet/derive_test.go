@@ -0,0 +1,66 @@
+package hdwallet
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func TestWalletAddressVector(t *testing.T) {
+	wallet, err := NewWallet(testMnemonic, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	address, err := wallet.Address(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := common.HexToAddress("0x9858EfFD232B4033E47d90003D41EC34EcaEda94")
+	if address != want {
+		t.Fatalf("m/44'/60'/0'/0/0 = %s, want %s", address.Hex(), want.Hex())
+	}
+}
+
+// TestChildVector checks the private keys of BIP-32 test vector 1.
+func TestChildVector(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	key, err := masterKey(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	steps := []struct {
+		path  string
+		index uint32
+		want  string
+	}{
+		{"m/0'", 0 + hardened, "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea"},
+		{"m/0'/1", 1, "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368"},
+		{"m/0'/1/2'", 2 + hardened, "cbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca"},
+	}
+	if got := hex.EncodeToString(math.PaddedBigBytes(key.key, 32)); got != "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35" {
+		t.Fatalf("m = %s", got)
+	}
+	for _, step := range steps {
+		if key, err = key.child(step.index); err != nil {
+			t.Fatalf("%s: %v", step.path, err)
+		}
+		if got := hex.EncodeToString(math.PaddedBigBytes(key.key, 32)); got != step.want {
+			t.Fatalf("%s = %s, want %s", step.path, got, step.want)
+		}
+	}
+}
+
+func TestPrivateKeyRejectsHardenedIndex(t *testing.T) {
+	wallet, err := NewWallet(testMnemonic, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := wallet.PrivateKey(hardened); err == nil {
+		t.Fatal("expected an error for a hardened index")
+	}
+}
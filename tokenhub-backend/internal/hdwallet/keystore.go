@@ -0,0 +1,63 @@
+package hdwallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+)
+
+// mnemonicFile is the on-disk format of an encrypted mnemonic: the same
+// scrypt/AES-128-CTR envelope as a Web3 Secret Storage key file.
+type mnemonicFile struct {
+	Version int                 `json:"version"`
+	Crypto  keystore.CryptoJSON `json:"crypto"`
+}
+
+const mnemonicFileVersion = 1
+
+// SaveMnemonic encrypts mnemonic with passphrase and writes it to path,
+// refusing to overwrite an existing file.
+func SaveMnemonic(path, mnemonic, passphrase string) error {
+	cryptoJSON, err := keystore.EncryptDataV3([]byte(mnemonic), []byte(passphrase), keystore.StandardScryptN, keystore.StandardScryptP)
+	if err != nil {
+		return err
+	}
+	raw, err := json.MarshalIndent(mnemonicFile{Version: mnemonicFileVersion, Crypto: cryptoJSON}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(raw); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// LoadMnemonic decrypts the mnemonic stored at path.
+func LoadMnemonic(path, passphrase string) (string, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	var file mnemonicFile
+	if err := json.Unmarshal(raw, &file); err != nil {
+		return "", fmt.Errorf("parsing %s: %v", path, err)
+	}
+	if file.Version != mnemonicFileVersion {
+		return "", fmt.Errorf("%s: unsupported version %d", path, file.Version)
+	}
+
+	mnemonic, err := keystore.DecryptDataV3(file.Crypto, passphrase)
+	if err != nil {
+		return "", fmt.Errorf("decrypting %s: %v", path, err)
+	}
+	return string(mnemonic), nil
+}
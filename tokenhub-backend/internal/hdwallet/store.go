@@ -0,0 +1,142 @@
+package hdwallet
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	_ "modernc.org/sqlite"
+)
+
+// migrations are applied in order; PRAGMA user_version records how many have run.
+var migrations = []string{
+	`
+CREATE TABLE IF NOT EXISTS wallets (
+	id         INTEGER PRIMARY KEY,
+	address    TEXT NOT NULL UNIQUE,
+	label      TEXT NOT NULL DEFAULT '',
+	created_at INTEGER NOT NULL
+);
+`,
+}
+
+// Account is an allocated deposit address. ID is also its derivation index.
+type Account struct {
+	ID        uint32         `json:"id"`
+	Address   common.Address `json:"address"`
+	Path      string         `json:"derivationPath"`
+	Label     string         `json:"label,omitempty"`
+	CreatedAt time.Time      `json:"createdAt"`
+}
+
+// Store records which derivation indexes have been handed out.
+type Store struct {
+	db *sql.DB
+}
+
+// OpenStore opens (creating if needed) the SQLite database at path.
+func OpenStore(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	// A single connection also serialises allocations of the next index.
+	db.SetMaxOpenConns(1)
+
+	if err := migrate(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("error migrating wallet schema: %v", err)
+	}
+	return &Store{db: db}, nil
+}
+
+func migrate(db *sql.DB) error {
+	var version int
+	if err := db.QueryRow(`PRAGMA user_version`).Scan(&version); err != nil {
+		return err
+	}
+
+	for i := version; i < len(migrations); i++ {
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(migrations[i]); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %d: %v", i+1, err)
+		}
+		if _, err := tx.Exec(fmt.Sprintf(`PRAGMA user_version = %d`, i+1)); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Allocate reserves the next unused index and records the address wallet
+// derives for it.
+func (s *Store) Allocate(wallet *Wallet, label string) (*Account, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var next uint32
+	if err := tx.QueryRow(`SELECT COALESCE(MAX(id) + 1, 0) FROM wallets`).Scan(&next); err != nil {
+		return nil, err
+	}
+
+	address, err := wallet.Address(next)
+	for errors.Is(err, errInvalidChild) {
+		// BIP-32: an index whose key is invalid is skipped; it is never allocated.
+		log.Printf("Skipping HD wallet index %d: %v", next, err)
+		next++
+		address, err = wallet.Address(next)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	account := &Account{ID: next, Address: address, Path: Path(next), Label: label, CreatedAt: time.Now().UTC().Truncate(time.Second)}
+	if _, err := tx.Exec(
+		`INSERT INTO wallets (id, address, label, created_at) VALUES (?, ?, ?, ?)`,
+		account.ID, address.Hex(), label, account.CreatedAt.Unix(),
+	); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// Account returns an allocated account; ok is false when id was never allocated.
+func (s *Store) Account(id uint32) (*Account, bool, error) {
+	var (
+		address   string
+		createdAt int64
+	)
+	account := Account{ID: id, Path: Path(id)}
+	err := s.db.QueryRow(`SELECT address, label, created_at FROM wallets WHERE id = ?`, id).
+		Scan(&address, &account.Label, &createdAt)
+	if err == sql.ErrNoRows {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	account.Address = common.HexToAddress(address)
+	account.CreatedAt = time.Unix(createdAt, 0).UTC()
+	return &account, true, nil
+}
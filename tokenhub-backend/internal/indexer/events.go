@@ -0,0 +1,309 @@
+package indexer
+
+import (
+	"math/big"
+	"strconv"
+	"time"
+	erc1155 "tokenhub-api/contracts/ERC1155"
+	erc20 "tokenhub-api/contracts/ERC20"
+	erc721 "tokenhub-api/contracts/ERC721"
+	"tokenhub-api/internal/utils"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Event names as emitted by the TokenHub contracts.
+const (
+	EventTransfer       = "Transfer"
+	EventApproval       = "Approval"
+	EventApprovalForAll = "ApprovalForAll"
+	EventPaused         = "Paused"
+	EventUnpaused       = "Unpaused"
+	EventMetadataUpdate = "MetadataUpdate"
+	EventTransferSingle = "TransferSingle"
+	EventTransferBatch  = "TransferBatch"
+	EventMint           = "Mint"
+	EventURI            = "URI"
+)
+
+// Event is a decoded contract log. TransferBatch logs are flattened into one
+// Event per id, distinguished by BatchIndex.
+type Event struct {
+	Contract    common.Address
+	Standard    string
+	Name        string
+	BlockNumber uint64
+	BlockHash   common.Hash
+	BlockTime   time.Time
+	TxHash      common.Hash
+	LogIndex    uint
+	BatchIndex  int
+	From        common.Address
+	To          common.Address
+	Operator    common.Address
+	TokenID     *big.Int
+	Amount      *big.Int
+	// Data carries event specific extras such as a token URI or an approval flag.
+	Data string
+	// Final is set once the block is buried under the configured confirmation depth.
+	Final bool
+}
+
+// logIterator is the common surface of the generated Filter* iterators.
+type logIterator interface {
+	Next() bool
+	Error() error
+	Close() error
+}
+
+func collect(it logIterator, decode func() []Event) ([]Event, error) {
+	defer it.Close()
+
+	var events []Event
+	for it.Next() {
+		events = append(events, decode()...)
+	}
+	return events, it.Error()
+}
+
+func newEvent(standard, name string, raw types.Log) Event {
+	return Event{
+		Contract:    raw.Address,
+		Standard:    standard,
+		Name:        name,
+		BlockNumber: raw.BlockNumber,
+		BlockHash:   raw.BlockHash,
+		TxHash:      raw.TxHash,
+		LogIndex:    raw.Index,
+	}
+}
+
+// filterFunc fetches the decoded events of one contract in a block range.
+type filterFunc func(client bind.ContractBackend, contract common.Address, opts *bind.FilterOpts) ([]Event, error)
+
+var filters = map[string]filterFunc{
+	utils.StandardERC20:   filterERC20,
+	utils.StandardERC721:  filterERC721,
+	utils.StandardERC1155: filterERC1155,
+}
+
+func filterERC20(client bind.ContractBackend, contract common.Address, opts *bind.FilterOpts) ([]Event, error) {
+	instance, err := erc20.NewContracts(contract, client)
+	if err != nil {
+		return nil, err
+	}
+	const std = utils.StandardERC20
+	var events []Event
+
+	transfers, err := instance.FilterTransfer(opts, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	batch, err := collect(transfers, func() []Event {
+		e := newEvent(std, EventTransfer, transfers.Event.Raw)
+		e.From, e.To, e.Amount = transfers.Event.From, transfers.Event.To, transfers.Event.Value
+		return []Event{e}
+	})
+	if err != nil {
+		return nil, err
+	}
+	events = append(events, batch...)
+
+	approvals, err := instance.FilterApproval(opts, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	batch, err = collect(approvals, func() []Event {
+		e := newEvent(std, EventApproval, approvals.Event.Raw)
+		e.From, e.To, e.Amount = approvals.Event.Owner, approvals.Event.Spender, approvals.Event.Value
+		return []Event{e}
+	})
+	if err != nil {
+		return nil, err
+	}
+	events = append(events, batch...)
+
+	paused, err := instance.FilterPaused(opts)
+	if err != nil {
+		return nil, err
+	}
+	batch, err = collect(paused, func() []Event {
+		e := newEvent(std, EventPaused, paused.Event.Raw)
+		e.Operator = paused.Event.Account
+		return []Event{e}
+	})
+	if err != nil {
+		return nil, err
+	}
+	events = append(events, batch...)
+
+	unpaused, err := instance.FilterUnpaused(opts)
+	if err != nil {
+		return nil, err
+	}
+	batch, err = collect(unpaused, func() []Event {
+		e := newEvent(std, EventUnpaused, unpaused.Event.Raw)
+		e.Operator = unpaused.Event.Account
+		return []Event{e}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append(events, batch...), nil
+}
+
+func filterERC721(client bind.ContractBackend, contract common.Address, opts *bind.FilterOpts) ([]Event, error) {
+	instance, err := erc721.NewContracts(contract, client)
+	if err != nil {
+		return nil, err
+	}
+	const std = utils.StandardERC721
+	var events []Event
+
+	transfers, err := instance.FilterTransfer(opts, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	batch, err := collect(transfers, func() []Event {
+		e := newEvent(std, EventTransfer, transfers.Event.Raw)
+		e.From, e.To, e.TokenID = transfers.Event.From, transfers.Event.To, transfers.Event.TokenId
+		e.Amount = big.NewInt(1)
+		return []Event{e}
+	})
+	if err != nil {
+		return nil, err
+	}
+	events = append(events, batch...)
+
+	approvals, err := instance.FilterApproval(opts, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	batch, err = collect(approvals, func() []Event {
+		e := newEvent(std, EventApproval, approvals.Event.Raw)
+		e.From, e.To, e.TokenID = approvals.Event.Owner, approvals.Event.Approved, approvals.Event.TokenId
+		return []Event{e}
+	})
+	if err != nil {
+		return nil, err
+	}
+	events = append(events, batch...)
+
+	operators, err := instance.FilterApprovalForAll(opts, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	batch, err = collect(operators, func() []Event {
+		e := newEvent(std, EventApprovalForAll, operators.Event.Raw)
+		e.From, e.Operator = operators.Event.Owner, operators.Event.Operator
+		e.Data = strconv.FormatBool(operators.Event.Approved)
+		return []Event{e}
+	})
+	if err != nil {
+		return nil, err
+	}
+	events = append(events, batch...)
+
+	updates, err := instance.FilterMetadataUpdate(opts)
+	if err != nil {
+		return nil, err
+	}
+	batch, err = collect(updates, func() []Event {
+		e := newEvent(std, EventMetadataUpdate, updates.Event.Raw)
+		e.TokenID = updates.Event.TokenId
+		return []Event{e}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append(events, batch...), nil
+}
+
+func filterERC1155(client bind.ContractBackend, contract common.Address, opts *bind.FilterOpts) ([]Event, error) {
+	instance, err := erc1155.NewContracts(contract, client)
+	if err != nil {
+		return nil, err
+	}
+	const std = utils.StandardERC1155
+	var events []Event
+
+	singles, err := instance.FilterTransferSingle(opts, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	batch, err := collect(singles, func() []Event {
+		e := newEvent(std, EventTransferSingle, singles.Event.Raw)
+		e.Operator, e.From, e.To = singles.Event.Operator, singles.Event.From, singles.Event.To
+		e.TokenID, e.Amount = singles.Event.Id, singles.Event.Value
+		return []Event{e}
+	})
+	if err != nil {
+		return nil, err
+	}
+	events = append(events, batch...)
+
+	batches, err := instance.FilterTransferBatch(opts, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	batch, err = collect(batches, func() []Event {
+		out := make([]Event, len(batches.Event.Ids))
+		for i, id := range batches.Event.Ids {
+			e := newEvent(std, EventTransferBatch, batches.Event.Raw)
+			e.Operator, e.From, e.To = batches.Event.Operator, batches.Event.From, batches.Event.To
+			e.TokenID, e.Amount, e.BatchIndex = id, batches.Event.Values[i], i
+			out[i] = e
+		}
+		return out
+	})
+	if err != nil {
+		return nil, err
+	}
+	events = append(events, batch...)
+
+	mints, err := instance.FilterMint(opts, nil)
+	if err != nil {
+		return nil, err
+	}
+	batch, err = collect(mints, func() []Event {
+		e := newEvent(std, EventMint, mints.Event.Raw)
+		e.To, e.TokenID, e.Amount = mints.Event.To, mints.Event.TokenId, mints.Event.Amount
+		e.Data = mints.Event.TokenURI
+		return []Event{e}
+	})
+	if err != nil {
+		return nil, err
+	}
+	events = append(events, batch...)
+
+	uris, err := instance.FilterURI(opts, nil)
+	if err != nil {
+		return nil, err
+	}
+	batch, err = collect(uris, func() []Event {
+		e := newEvent(std, EventURI, uris.Event.Raw)
+		e.TokenID, e.Data = uris.Event.Id, uris.Event.Value
+		return []Event{e}
+	})
+	if err != nil {
+		return nil, err
+	}
+	events = append(events, batch...)
+
+	operators, err := instance.FilterApprovalForAll(opts, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	batch, err = collect(operators, func() []Event {
+		e := newEvent(std, EventApprovalForAll, operators.Event.Raw)
+		e.From, e.Operator = operators.Event.Account, operators.Event.Operator
+		e.Data = strconv.FormatBool(operators.Event.Approved)
+		return []Event{e}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append(events, batch...), nil
+}
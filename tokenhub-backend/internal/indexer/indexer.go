@@ -0,0 +1,348 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+	"time"
+	"tokenhub-api/internal/rpcpool"
+	"tokenhub-api/internal/utils"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+type Config struct {
+	// PollInterval is how often the indexer checks for new blocks.
+	PollInterval time.Duration
+	// BatchSize is the maximum number of blocks requested per eth_getLogs range.
+	BatchSize uint64
+	// Confirmations is how many blocks must be mined on top of a block before
+	// its events are marked final and its hash is no longer checked for reorgs.
+	Confirmations uint64
+}
+
+func DefaultConfig() Config {
+	return Config{
+		PollInterval:  15 * time.Second,
+		BatchSize:     5000,
+		Confirmations: 12,
+	}
+}
+
+var (
+	// ErrNotTracked is returned when reading events of a contract the indexer does not follow.
+	ErrNotTracked = errors.New("contract is not indexed; register it with POST /api/indexer/contracts")
+	// errReorg aborts a sync when the chain changed underneath the indexer.
+	errReorg = errors.New("chain reorganization detected")
+)
+
+// Backend is the part of the RPC client the indexer uses; *rpcpool.Pool
+// satisfies it.
+type Backend interface {
+	bind.ContractBackend
+	BlockNumber(ctx context.Context) (uint64, error)
+}
+
+var _ Backend = (*rpcpool.Pool)(nil)
+
+// Indexer polls the Filter* bindings of every tracked contract and stores the
+// decoded events. Progress is checkpointed per contract, so a restarted
+// indexer resumes from the last processed block.
+type Indexer struct {
+	client Backend
+	store  *Store
+	cfg    Config
+}
+
+func New(client Backend, store *Store, cfg Config) *Indexer {
+	return &Indexer{client: client, store: store, cfg: cfg}
+}
+
+func (idx *Indexer) Store() *Store {
+	return idx.store
+}
+
+// Track registers a contract to be indexed from startBlock onwards.
+func (idx *Indexer) Track(contract common.Address, standard string, startBlock uint64) error {
+	if !utils.IsValidStandard(standard) {
+		return fmt.Errorf("unsupported standard %q", standard)
+	}
+	return idx.store.AddContract(contract, standard, startBlock)
+}
+
+func (idx *Indexer) Contracts() ([]TrackedContract, error) {
+	return idx.store.Contracts()
+}
+
+// Latest returns the events of filter.Contract that match filter, in chain
+// order: those stored up to the contract's checkpoint, followed by those mined
+// since, read from the node when the checkpoint trails the head by at most one
+// batch. While a backfill is further behind, complete is false and the events
+// stop at the checkpoint. Limit, Offset and Descending are ignored.
+func (idx *Indexer) Latest(ctx context.Context, filter EventFilter) (events []Event, complete bool, err error) {
+	c, ok, err := idx.store.Contract(filter.Contract)
+	if err != nil {
+		return nil, false, err
+	}
+	if !ok {
+		return nil, false, ErrNotTracked
+	}
+
+	filter.Limit, filter.Offset, filter.Descending = 0, 0, false
+	stored, err := idx.store.Events(filter)
+	if err != nil {
+		return nil, false, err
+	}
+	// A sync may have saved blocks past c.LastBlock since c was read; those
+	// are covered by the tail below.
+	for _, e := range stored {
+		if e.BlockNumber <= c.LastBlock {
+			events = append(events, e)
+		}
+	}
+
+	head, err := idx.client.BlockNumber(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	if c.LastBlock >= head || filter.FinalOnly {
+		return events, true, nil
+	}
+	if head-c.LastBlock > idx.cfg.BatchSize {
+		return events, false, nil
+	}
+
+	tail, err := filters[c.Standard](idx.client, c.Address, &bind.FilterOpts{Start: c.LastBlock + 1, End: &head, Context: ctx})
+	if err != nil {
+		return nil, false, err
+	}
+	if _, err := idx.stampBlockTimes(ctx, tail); err != nil {
+		return nil, false, err
+	}
+	for _, e := range tail {
+		if filter.matches(e) {
+			events = append(events, e)
+		}
+	}
+	return events, true, nil
+}
+
+// Run indexes until ctx is cancelled.
+func (idx *Indexer) Run(ctx context.Context) {
+	ticker := time.NewTicker(idx.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := idx.Sync(ctx); err != nil {
+			log.Println("Indexer sync failed:", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync rolls back any reorganized blocks, brings every tracked contract up to
+// the current chain head and finalizes events past the confirmation depth.
+func (idx *Indexer) Sync(ctx context.Context) error {
+	if err := idx.handleReorg(ctx); err != nil {
+		return err
+	}
+
+	head, err := idx.client.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+
+	contracts, err := idx.store.Contracts()
+	if err != nil {
+		return err
+	}
+
+	for _, c := range contracts {
+		err := idx.syncContract(ctx, c, head)
+		if errors.Is(err, errReorg) {
+			log.Printf("Indexer stopped at %s: %v", c.Address.Hex(), err)
+			return idx.handleReorg(ctx)
+		}
+		if err != nil {
+			log.Printf("Indexer failed for %s: %v", c.Address.Hex(), err)
+		}
+	}
+
+	if head >= idx.cfg.Confirmations {
+		return idx.store.Finalize(head - idx.cfg.Confirmations)
+	}
+	return nil
+}
+
+// handleReorg compares the stored hashes of unfinalized blocks with the
+// canonical chain, newest first, and rolls back everything above the most
+// recent block that still matches.
+func (idx *Indexer) handleReorg(ctx context.Context) error {
+	blocks, err := idx.store.RecentBlocks()
+	if err != nil || len(blocks) == 0 {
+		return err
+	}
+
+	var ancestor uint64
+	found := false
+	for _, b := range blocks {
+		header, err := idx.client.HeaderByNumber(ctx, new(big.Int).SetUint64(b.Number))
+		if err != nil {
+			return err
+		}
+		if header.Hash() == b.Hash {
+			ancestor, found = b.Number, true
+			break
+		}
+	}
+
+	if found && ancestor == blocks[0].Number {
+		return nil
+	}
+	if !found {
+		// The reorg is deeper than every tracked block, i.e. deeper than the
+		// confirmation depth; re-index from just below the oldest one.
+		oldest := blocks[len(blocks)-1].Number
+		log.Printf("Reorg deeper than %d confirmations below block %d", idx.cfg.Confirmations, oldest)
+		if oldest > 0 {
+			ancestor = oldest - 1
+		}
+	}
+
+	removed, err := idx.store.Rollback(ancestor)
+	if err != nil {
+		return err
+	}
+	log.Printf("Chain reorganization: rolled back to block %d, removed %d events", ancestor, removed)
+	return nil
+}
+
+func (idx *Indexer) syncContract(ctx context.Context, c TrackedContract, head uint64) error {
+	filter, ok := filters[c.Standard]
+	if !ok {
+		return fmt.Errorf("unsupported standard %q", c.Standard)
+	}
+
+	// The genesis block carries no logs, so a checkpoint of 0 safely means "nothing indexed yet".
+	next := c.LastBlock + 1
+	for next <= head {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		end := min(next+idx.cfg.BatchSize-1, head)
+		events, err := filter(idx.client, c.Address, &bind.FilterOpts{Start: next, End: &end, Context: ctx})
+		if err != nil {
+			return err
+		}
+
+		headers, err := idx.stampBlockTimes(ctx, events)
+		if err != nil {
+			return err
+		}
+
+		blocks, err := idx.unfinalizedBlocks(ctx, headers, next, end, head)
+		if err != nil {
+			return err
+		}
+
+		for i := range events {
+			events[i].Final = events[i].BlockNumber+idx.cfg.Confirmations <= head
+		}
+
+		if err := idx.store.SaveEvents(c.Address, events, blocks, end); err != nil {
+			return err
+		}
+		if len(events) > 0 {
+			log.Printf("Indexed %d events for %s up to block %d", len(events), c.Address.Hex(), end)
+		}
+		next = end + 1
+	}
+	return nil
+}
+
+// stampBlockTimes fills in BlockTime, fetching each distinct block header once,
+// and orders the events as they appeared on chain. A header whose hash differs
+// from the log's block hash means the block was reorganized mid-scan.
+func (idx *Indexer) stampBlockTimes(ctx context.Context, events []Event) (map[uint64]*types.Header, error) {
+	headers := make(map[uint64]*types.Header)
+	for i := range events {
+		number := events[i].BlockNumber
+		header, ok := headers[number]
+		if !ok {
+			var err error
+			header, err = idx.client.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+			if err != nil {
+				return nil, err
+			}
+			headers[number] = header
+		}
+		if header.Hash() != events[i].BlockHash {
+			return nil, fmt.Errorf("%w: block %d hash changed", errReorg, number)
+		}
+		events[i].BlockTime = time.Unix(int64(header.Time), 0).UTC()
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		a, b := events[i], events[j]
+		if a.BlockNumber != b.BlockNumber {
+			return a.BlockNumber < b.BlockNumber
+		}
+		if a.LogIndex != b.LogIndex {
+			return a.LogIndex < b.LogIndex
+		}
+		return a.BatchIndex < b.BatchIndex
+	})
+	return headers, nil
+}
+
+// unfinalizedBlocks returns references for every block in [start, end] that is
+// still within the confirmation depth of head, verifying that each one's parent
+// hash links to the block before it.
+func (idx *Indexer) unfinalizedBlocks(ctx context.Context, headers map[uint64]*types.Header, start, end, head uint64) ([]BlockRef, error) {
+	if head >= idx.cfg.Confirmations && head-idx.cfg.Confirmations >= start {
+		start = head - idx.cfg.Confirmations
+	}
+	if start > end {
+		return nil, nil
+	}
+
+	var blocks []BlockRef
+	for number := start; number <= end; number++ {
+		header, ok := headers[number]
+		if !ok {
+			var err error
+			header, err = idx.client.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if len(blocks) > 0 {
+			if header.ParentHash != blocks[len(blocks)-1].Hash {
+				return nil, fmt.Errorf("%w: block %d does not extend block %d", errReorg, number, number-1)
+			}
+		} else if number > 0 {
+			parent, ok, err := idx.store.BlockHash(number - 1)
+			if err != nil {
+				return nil, err
+			}
+			if ok && parent != header.ParentHash {
+				return nil, fmt.Errorf("%w: parent of block %d no longer matches", errReorg, number)
+			}
+		}
+
+		blocks = append(blocks, BlockRef{Number: number, Hash: header.Hash(), ParentHash: header.ParentHash})
+	}
+	return blocks, nil
+}
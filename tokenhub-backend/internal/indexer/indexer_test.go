@@ -0,0 +1,288 @@
+package indexer
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+	erc20 "tokenhub-api/contracts/ERC20"
+	"tokenhub-api/internal/utils"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	testContract = common.HexToAddress("0x00000000000000000000000000000000000C0DE0")
+	testWallet   = common.HexToAddress("0x000000000000000000000000000000000000A11C")
+)
+
+// fakeChain serves headers and ERC20 Transfer logs of a chain whose blocks
+// can be replaced by a fork. Logs belong to a block hash, so they disappear
+// with the block when it is reorganized away.
+type fakeChain struct {
+	bind.ContractBackend // unused methods panic
+
+	mu      sync.Mutex
+	headers []*types.Header
+	logs    map[common.Hash][]types.Log
+
+	// onFilter runs once, after the first FilterLogs call has been answered.
+	onFilter func()
+}
+
+// newFakeChain builds blocks 0..head.
+func newFakeChain(head uint64) *fakeChain {
+	c := &fakeChain{logs: make(map[common.Hash][]types.Log)}
+	c.extend(0, head, 0)
+	return c
+}
+
+// extend replaces blocks from start onwards with a fork up to head; fork
+// keeps the new blocks' hashes distinct from those they replace.
+func (c *fakeChain) extend(start, head uint64, fork byte) {
+	c.headers = c.headers[:start]
+	for n := start; n <= head; n++ {
+		var parent common.Hash
+		if n > 0 {
+			parent = c.headers[n-1].Hash()
+		}
+		c.headers = append(c.headers, &types.Header{
+			ParentHash: parent,
+			Number:     new(big.Int).SetUint64(n),
+			Time:       1_700_000_000 + n*12,
+			Difficulty: common.Big0,
+			Extra:      []byte{fork},
+		})
+	}
+}
+
+func (c *fakeChain) reorg(start, head uint64, fork byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.extend(start, head, fork)
+}
+
+// transfer adds a Transfer of one token to testWallet in block number.
+func (c *fakeChain) transfer(number uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	abi, err := erc20.ContractsMetaData.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+	header := c.headers[number]
+	hash := header.Hash()
+	c.logs[hash] = append(c.logs[hash], types.Log{
+		Address: testContract,
+		Topics: []common.Hash{
+			abi.Events["Transfer"].ID,
+			common.BytesToHash(common.Address{}.Bytes()),
+			common.BytesToHash(testWallet.Bytes()),
+		},
+		Data:        common.LeftPadBytes(big.NewInt(1).Bytes(), 32),
+		BlockNumber: number,
+		BlockHash:   hash,
+		TxHash:      common.BytesToHash(append(hash.Bytes()[:8], byte(len(c.logs[hash])))),
+		Index:       uint(len(c.logs[hash])),
+	})
+}
+
+func (c *fakeChain) BlockNumber(context.Context) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return uint64(len(c.headers) - 1), nil
+}
+
+func (c *fakeChain) HeaderByNumber(_ context.Context, number *big.Int) (*types.Header, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if number == nil {
+		return c.headers[len(c.headers)-1], nil
+	}
+	if !number.IsUint64() || number.Uint64() >= uint64(len(c.headers)) {
+		return nil, ethereum.NotFound
+	}
+	return c.headers[number.Uint64()], nil
+}
+
+func (c *fakeChain) FilterLogs(_ context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	c.mu.Lock()
+	to := uint64(len(c.headers) - 1)
+	if q.ToBlock != nil {
+		to = min(to, q.ToBlock.Uint64())
+	}
+	var logs []types.Log
+	for n := q.FromBlock.Uint64(); n <= to; n++ {
+		for _, l := range c.logs[c.headers[n].Hash()] {
+			if len(q.Topics) > 0 && len(q.Topics[0]) > 0 && q.Topics[0][0] != l.Topics[0] {
+				continue
+			}
+			logs = append(logs, l)
+		}
+	}
+	hook := c.onFilter
+	c.onFilter = nil
+	c.mu.Unlock()
+
+	if hook != nil {
+		hook()
+	}
+	return logs, nil
+}
+
+func newTestIndexer(t *testing.T, chain *fakeChain, confirmations uint64) *Indexer {
+	t.Helper()
+	idx := New(chain, openTestStore(t), Config{PollInterval: time.Second, BatchSize: 100, Confirmations: confirmations})
+	if err := idx.Track(testContract, utils.StandardERC20, 1); err != nil {
+		t.Fatal(err)
+	}
+	return idx
+}
+
+func syncOnce(t *testing.T, idx *Indexer) {
+	t.Helper()
+	if err := idx.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+}
+
+func checkpoint(t *testing.T, idx *Indexer) uint64 {
+	t.Helper()
+	c, ok, err := idx.Store().Contract(testContract)
+	if err != nil || !ok {
+		t.Fatalf("contract not tracked: %v", err)
+	}
+	return c.LastBlock
+}
+
+// expectEvents checks the blocks holding indexed events, and which are final.
+func expectEvents(t *testing.T, idx *Indexer, want map[uint64]bool) {
+	t.Helper()
+	got := eventBlocks(t, idx.Store(), EventFilter{Contract: testContract})
+	if len(got) != len(want) {
+		t.Fatalf("events by block (final) = %v, want %v", got, want)
+	}
+	for block, final := range want {
+		if f, ok := got[block]; !ok || f != final {
+			t.Fatalf("events by block (final) = %v, want %v", got, want)
+		}
+	}
+}
+
+// expectCanonical checks that every tracked block hash is on the chain.
+func expectCanonical(t *testing.T, idx *Indexer, chain *fakeChain) {
+	t.Helper()
+	blocks, err := idx.Store().RecentBlocks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, b := range blocks {
+		header, _ := chain.HeaderByNumber(context.Background(), new(big.Int).SetUint64(b.Number))
+		if header.Hash() != b.Hash {
+			t.Fatalf("tracked block %d is not canonical", b.Number)
+		}
+	}
+}
+
+func TestSyncFinalizesPastConfirmations(t *testing.T) {
+	chain := newFakeChain(20)
+	chain.transfer(3)
+	chain.transfer(18)
+	idx := newTestIndexer(t, chain, 5)
+
+	syncOnce(t, idx)
+
+	expectEvents(t, idx, map[uint64]bool{3: true, 18: false})
+	if got := checkpoint(t, idx); got != 20 {
+		t.Fatalf("checkpoint = %d, want 20", got)
+	}
+	blocks, err := idx.Store().RecentBlocks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if oldest := blocks[len(blocks)-1].Number; oldest != 15 {
+		t.Fatalf("oldest tracked block = %d, want 15", oldest)
+	}
+
+	chain.reorg(21, 25, 0)
+	syncOnce(t, idx)
+	expectEvents(t, idx, map[uint64]bool{3: true, 18: true})
+}
+
+func TestSyncRollsBackToCommonAncestor(t *testing.T) {
+	chain := newFakeChain(20)
+	chain.transfer(3)
+	chain.transfer(18)
+	idx := newTestIndexer(t, chain, 5)
+	syncOnce(t, idx)
+
+	// Blocks 18-20 are replaced; the fork moves the transfer to block 19.
+	chain.reorg(18, 21, 1)
+	chain.transfer(19)
+	syncOnce(t, idx)
+
+	expectEvents(t, idx, map[uint64]bool{3: true, 19: false})
+	if got := checkpoint(t, idx); got != 21 {
+		t.Fatalf("checkpoint = %d, want 21", got)
+	}
+	expectCanonical(t, idx, chain)
+}
+
+func TestSyncHandlesReorgDeeperThanConfirmations(t *testing.T) {
+	chain := newFakeChain(20)
+	chain.transfer(18)
+	idx := newTestIndexer(t, chain, 3)
+	syncOnce(t, idx)
+
+	// Every tracked block (17-20) is replaced, so no common ancestor is
+	// known; the indexer rewinds to just below the oldest tracked block.
+	chain.reorg(10, 22, 1)
+	chain.transfer(19)
+	if err := idx.handleReorg(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := checkpoint(t, idx); got != 16 {
+		t.Fatalf("checkpoint after deep reorg = %d, want 16", got)
+	}
+	expectEvents(t, idx, map[uint64]bool{})
+
+	syncOnce(t, idx)
+	expectEvents(t, idx, map[uint64]bool{19: true})
+	if got := checkpoint(t, idx); got != 22 {
+		t.Fatalf("checkpoint = %d, want 22", got)
+	}
+	expectCanonical(t, idx, chain)
+}
+
+func TestSyncStopsOnReorgMidScan(t *testing.T) {
+	chain := newFakeChain(10)
+	chain.transfer(5)
+	idx := newTestIndexer(t, chain, 3)
+	syncOnce(t, idx)
+
+	chain.reorg(11, 15, 0)
+	chain.transfer(12)
+	// Block 12 is reorganized between fetching its logs and its header.
+	chain.onFilter = func() {
+		chain.reorg(12, 15, 1)
+	}
+	syncOnce(t, idx)
+
+	expectEvents(t, idx, map[uint64]bool{5: true})
+	if got := checkpoint(t, idx); got != 10 {
+		t.Fatalf("checkpoint after aborted scan = %d, want 10", got)
+	}
+
+	chain.transfer(13)
+	syncOnce(t, idx)
+	expectEvents(t, idx, map[uint64]bool{5: true, 13: false})
+	if got := checkpoint(t, idx); got != 15 {
+		t.Fatalf("checkpoint = %d, want 15", got)
+	}
+	expectCanonical(t, idx, chain)
+}
@@ -0,0 +1,436 @@
+package indexer
+
+import (
+	"database/sql"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	_ "modernc.org/sqlite"
+)
+
+// migrations are applied in order; PRAGMA user_version records how many have run.
+var migrations = []string{
+	`
+CREATE TABLE IF NOT EXISTS contracts (
+	address     TEXT PRIMARY KEY,
+	standard    TEXT NOT NULL,
+	start_block INTEGER NOT NULL,
+	last_block  INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS events (
+	contract     TEXT NOT NULL,
+	standard     TEXT NOT NULL,
+	name         TEXT NOT NULL,
+	block_number INTEGER NOT NULL,
+	block_hash   TEXT NOT NULL,
+	block_time   INTEGER NOT NULL,
+	tx_hash      TEXT NOT NULL,
+	log_index    INTEGER NOT NULL,
+	batch_index  INTEGER NOT NULL DEFAULT 0,
+	from_addr    TEXT NOT NULL DEFAULT '',
+	to_addr      TEXT NOT NULL DEFAULT '',
+	operator     TEXT NOT NULL DEFAULT '',
+	token_id     TEXT NOT NULL DEFAULT '',
+	amount       TEXT NOT NULL DEFAULT '',
+	data         TEXT NOT NULL DEFAULT '',
+	PRIMARY KEY (tx_hash, log_index, batch_index)
+);
+
+CREATE INDEX IF NOT EXISTS events_contract_block ON events (contract, block_number);
+CREATE INDEX IF NOT EXISTS events_from ON events (from_addr);
+CREATE INDEX IF NOT EXISTS events_to ON events (to_addr);
+`,
+	`
+CREATE TABLE IF NOT EXISTS blocks (
+	number      INTEGER PRIMARY KEY,
+	hash        TEXT NOT NULL,
+	parent_hash TEXT NOT NULL
+);
+
+ALTER TABLE events ADD COLUMN final INTEGER NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS events_block ON events (block_number);
+`,
+}
+
+// Store persists tracked contracts, their checkpoints and decoded events in SQLite.
+type Store struct {
+	db *sql.DB
+}
+
+// OpenStore opens (creating if needed) the SQLite database at path.
+func OpenStore(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	// SQLite allows a single writer; serialise access instead of retrying on SQLITE_BUSY.
+	db.SetMaxOpenConns(1)
+
+	if err := migrate(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("error migrating indexer schema: %v", err)
+	}
+	return &Store{db: db}, nil
+}
+
+func migrate(db *sql.DB) error {
+	var version int
+	if err := db.QueryRow(`PRAGMA user_version`).Scan(&version); err != nil {
+		return err
+	}
+
+	for i := version; i < len(migrations); i++ {
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(migrations[i]); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %d: %v", i+1, err)
+		}
+		if _, err := tx.Exec(fmt.Sprintf(`PRAGMA user_version = %d`, i+1)); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// TrackedContract is a contract followed by the indexer. LastBlock is the last
+// block whose events have been stored; indexing resumes at LastBlock+1.
+type TrackedContract struct {
+	Address    common.Address `json:"address"`
+	Standard   string         `json:"standard"`
+	StartBlock uint64         `json:"startBlock"`
+	LastBlock  uint64         `json:"lastBlock"`
+}
+
+// AddContract starts tracking a contract. Re-adding a tracked contract is a no-op,
+// so its checkpoint survives repeated registration.
+func (s *Store) AddContract(address common.Address, standard string, startBlock uint64) error {
+	lastBlock := uint64(0)
+	if startBlock > 0 {
+		lastBlock = startBlock - 1
+	}
+	_, err := s.db.Exec(
+		`INSERT OR IGNORE INTO contracts (address, standard, start_block, last_block) VALUES (?, ?, ?, ?)`,
+		address.Hex(), standard, startBlock, lastBlock,
+	)
+	return err
+}
+
+// Contract returns the tracking state of one contract.
+func (s *Store) Contract(address common.Address) (*TrackedContract, bool, error) {
+	c := TrackedContract{Address: address}
+	err := s.db.QueryRow(`SELECT standard, start_block, last_block FROM contracts WHERE address = ?`, address.Hex()).
+		Scan(&c.Standard, &c.StartBlock, &c.LastBlock)
+	if err == sql.ErrNoRows {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return &c, true, nil
+}
+
+func (s *Store) Contracts() ([]TrackedContract, error) {
+	rows, err := s.db.Query(`SELECT address, standard, start_block, last_block FROM contracts ORDER BY address`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var contracts []TrackedContract
+	for rows.Next() {
+		var (
+			c       TrackedContract
+			address string
+		)
+		if err := rows.Scan(&address, &c.Standard, &c.StartBlock, &c.LastBlock); err != nil {
+			return nil, err
+		}
+		c.Address = common.HexToAddress(address)
+		contracts = append(contracts, c)
+	}
+	return contracts, rows.Err()
+}
+
+// BlockRef identifies a block by number and hash, with its parent for chain linkage.
+type BlockRef struct {
+	Number     uint64
+	Hash       common.Hash
+	ParentHash common.Hash
+}
+
+// SaveEvents stores events and the hashes of not-yet-final blocks, and advances
+// the contract's checkpoint to lastBlock in one transaction, so a crash never
+// leaves a half-indexed range behind.
+func (s *Store) SaveEvents(contract common.Address, events []Event, blocks []BlockRef, lastBlock uint64) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`INSERT OR REPLACE INTO events (
+		contract, standard, name, block_number, block_hash, block_time, tx_hash, log_index,
+		batch_index, from_addr, to_addr, operator, token_id, amount, data, final
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, e := range events {
+		_, err := stmt.Exec(
+			e.Contract.Hex(), e.Standard, e.Name, e.BlockNumber, e.BlockHash.Hex(), e.BlockTime.Unix(),
+			e.TxHash.Hex(), e.LogIndex, e.BatchIndex, addressText(e.From), addressText(e.To),
+			addressText(e.Operator), bigText(e.TokenID), bigText(e.Amount), e.Data, e.Final,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, b := range blocks {
+		_, err := tx.Exec(`INSERT OR REPLACE INTO blocks (number, hash, parent_hash) VALUES (?, ?, ?)`,
+			b.Number, b.Hash.Hex(), b.ParentHash.Hex())
+		if err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.Exec(`UPDATE contracts SET last_block = ? WHERE address = ?`, lastBlock, contract.Hex()); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// EventFilter selects stored events. Zero-valued fields match everything.
+type EventFilter struct {
+	Contract common.Address
+	// Wallet matches events where the address is the sender, recipient or operator.
+	Wallet common.Address
+	Names  []string
+	// FinalOnly excludes events from blocks that are not yet past the confirmation depth.
+	FinalOnly bool
+	// Descending returns the newest events first.
+	Descending bool
+	Limit      int
+	Offset     int
+}
+
+// where builds the SQL condition and arguments for the filter.
+func (filter EventFilter) where() (string, []any) {
+	var (
+		where []string
+		args  []any
+	)
+	if filter.Contract != (common.Address{}) {
+		where = append(where, "contract = ?")
+		args = append(args, filter.Contract.Hex())
+	}
+	if filter.Wallet != (common.Address{}) {
+		where = append(where, "(from_addr = ? OR to_addr = ? OR operator = ?)")
+		wallet := filter.Wallet.Hex()
+		args = append(args, wallet, wallet, wallet)
+	}
+	if len(filter.Names) > 0 {
+		where = append(where, "name IN (?"+strings.Repeat(", ?", len(filter.Names)-1)+")")
+		for _, name := range filter.Names {
+			args = append(args, name)
+		}
+	}
+	if filter.FinalOnly {
+		where = append(where, "final = 1")
+	}
+
+	if len(where) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(where, " AND "), args
+}
+
+// matches applies the filter to an event that has not been stored.
+func (filter EventFilter) matches(e Event) bool {
+	if filter.Contract != (common.Address{}) && e.Contract != filter.Contract {
+		return false
+	}
+	if w := filter.Wallet; w != (common.Address{}) && e.From != w && e.To != w && e.Operator != w {
+		return false
+	}
+	if len(filter.Names) > 0 && !slices.Contains(filter.Names, e.Name) {
+		return false
+	}
+	return !filter.FinalOnly || e.Final
+}
+
+// CountEvents returns how many events match the filter, ignoring Limit and Offset.
+func (s *Store) CountEvents(filter EventFilter) (int, error) {
+	where, args := filter.where()
+	var count int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM events`+where, args...).Scan(&count)
+	return count, err
+}
+
+// Events returns matching events ordered by block, log index and batch index.
+func (s *Store) Events(filter EventFilter) ([]Event, error) {
+	where, args := filter.where()
+
+	query := `SELECT contract, standard, name, block_number, block_hash, block_time, tx_hash, log_index,
+		batch_index, from_addr, to_addr, operator, token_id, amount, data, final FROM events` + where
+	if filter.Descending {
+		query += " ORDER BY block_number DESC, log_index DESC, batch_index DESC"
+	} else {
+		query += " ORDER BY block_number, log_index, batch_index"
+	}
+	if filter.Limit > 0 {
+		query += " LIMIT ? OFFSET ?"
+		args = append(args, filter.Limit, filter.Offset)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []Event
+	for rows.Next() {
+		var (
+			e                                                  Event
+			contract, blockHash, txHash, from, to, op, id, amt string
+			blockTime                                          int64
+		)
+		err := rows.Scan(&contract, &e.Standard, &e.Name, &e.BlockNumber, &blockHash, &blockTime, &txHash,
+			&e.LogIndex, &e.BatchIndex, &from, &to, &op, &id, &amt, &e.Data, &e.Final)
+		if err != nil {
+			return nil, err
+		}
+		e.Contract = common.HexToAddress(contract)
+		e.BlockHash = common.HexToHash(blockHash)
+		e.BlockTime = time.Unix(blockTime, 0).UTC()
+		e.TxHash = common.HexToHash(txHash)
+		e.From = common.HexToAddress(from)
+		e.To = common.HexToAddress(to)
+		e.Operator = common.HexToAddress(op)
+		e.TokenID = parseBig(id)
+		e.Amount = parseBig(amt)
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// BlockHash returns the stored hash of block number, if it is tracked.
+func (s *Store) BlockHash(number uint64) (common.Hash, bool, error) {
+	var hash string
+	err := s.db.QueryRow(`SELECT hash FROM blocks WHERE number = ?`, number).Scan(&hash)
+	if err == sql.ErrNoRows {
+		return common.Hash{}, false, nil
+	}
+	if err != nil {
+		return common.Hash{}, false, err
+	}
+	return common.HexToHash(hash), true, nil
+}
+
+// RecentBlocks returns the tracked (not yet final) blocks, newest first.
+func (s *Store) RecentBlocks() ([]BlockRef, error) {
+	rows, err := s.db.Query(`SELECT number, hash, parent_hash FROM blocks ORDER BY number DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var blocks []BlockRef
+	for rows.Next() {
+		var (
+			b            BlockRef
+			hash, parent string
+		)
+		if err := rows.Scan(&b.Number, &hash, &parent); err != nil {
+			return nil, err
+		}
+		b.Hash = common.HexToHash(hash)
+		b.ParentHash = common.HexToHash(parent)
+		blocks = append(blocks, b)
+	}
+	return blocks, rows.Err()
+}
+
+// Rollback discards everything indexed above ancestor, the last block still on
+// the canonical chain, and rewinds contract checkpoints so the range is re-indexed.
+func (s *Store) Rollback(ancestor uint64) (int64, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`DELETE FROM events WHERE block_number > ?`, ancestor)
+	if err != nil {
+		return 0, err
+	}
+	removed, _ := res.RowsAffected()
+
+	if _, err := tx.Exec(`DELETE FROM blocks WHERE number > ?`, ancestor); err != nil {
+		return 0, err
+	}
+	if _, err := tx.Exec(`UPDATE contracts SET last_block = ? WHERE last_block > ?`, ancestor, ancestor); err != nil {
+		return 0, err
+	}
+	return removed, tx.Commit()
+}
+
+// Finalize marks events up to and including block number as final and stops
+// tracking the hashes of blocks below it.
+func (s *Store) Finalize(number uint64) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`UPDATE events SET final = 1 WHERE final = 0 AND block_number <= ?`, number); err != nil {
+		return err
+	}
+	// Keep the finalized block itself so the next block's parent hash can be checked.
+	if _, err := tx.Exec(`DELETE FROM blocks WHERE number < ?`, number); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func addressText(a common.Address) string {
+	if a == (common.Address{}) {
+		return ""
+	}
+	return a.Hex()
+}
+
+func bigText(v *big.Int) string {
+	if v == nil {
+		return ""
+	}
+	return v.String()
+}
+
+func parseBig(s string) *big.Int {
+	if s == "" {
+		return nil
+	}
+	v, _ := new(big.Int).SetString(s, 10)
+	return v
+}
@@ -0,0 +1,137 @@
+package indexer
+
+import (
+	"math/big"
+	"testing"
+	"time"
+	"tokenhub-api/internal/utils"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+	store, err := OpenStore(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func blockHash(number uint64) common.Hash {
+	return common.BigToHash(new(big.Int).SetUint64(1000 + number))
+}
+
+// saveBlocks stores one Transfer per block in [start, end] for contract and
+// tracks every block's hash.
+func saveBlocks(t *testing.T, store *Store, contract common.Address, start, end uint64) {
+	t.Helper()
+	var (
+		events []Event
+		blocks []BlockRef
+	)
+	for n := start; n <= end; n++ {
+		events = append(events, Event{
+			Contract:    contract,
+			Standard:    utils.StandardERC20,
+			Name:        EventTransfer,
+			BlockNumber: n,
+			BlockHash:   blockHash(n),
+			BlockTime:   time.Unix(int64(n), 0),
+			TxHash:      common.BigToHash(new(big.Int).SetUint64(n)),
+			Amount:      big.NewInt(1),
+		})
+		blocks = append(blocks, BlockRef{Number: n, Hash: blockHash(n), ParentHash: blockHash(n - 1)})
+	}
+	if err := store.SaveEvents(contract, events, blocks, end); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func eventBlocks(t *testing.T, store *Store, filter EventFilter) map[uint64]bool {
+	t.Helper()
+	events, err := store.Events(filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	final := make(map[uint64]bool)
+	for _, e := range events {
+		final[e.BlockNumber] = e.Final
+	}
+	return final
+}
+
+func TestStoreRollback(t *testing.T) {
+	store := openTestStore(t)
+	a := common.HexToAddress("0xa")
+	b := common.HexToAddress("0xb")
+	for _, c := range []common.Address{a, b} {
+		if err := store.AddContract(c, utils.StandardERC20, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	saveBlocks(t, store, a, 10, 12)
+	saveBlocks(t, store, b, 5, 5)
+
+	removed, err := store.Rollback(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed != 2 {
+		t.Fatalf("removed %d events, want 2", removed)
+	}
+
+	if got := eventBlocks(t, store, EventFilter{Contract: a}); len(got) != 1 || !hasKey(got, 10) {
+		t.Fatalf("events left for a at blocks %v, want only 10", got)
+	}
+	if _, ok, _ := store.BlockHash(11); ok {
+		t.Fatal("block 11 is still tracked after the rollback")
+	}
+	if hash, ok, _ := store.BlockHash(10); !ok || hash != blockHash(10) {
+		t.Fatal("the common ancestor was not kept")
+	}
+
+	ca, _, _ := store.Contract(a)
+	cb, _, _ := store.Contract(b)
+	if ca.LastBlock != 10 {
+		t.Fatalf("checkpoint of a = %d, want 10", ca.LastBlock)
+	}
+	if cb.LastBlock != 5 {
+		t.Fatalf("checkpoint of b = %d, want it untouched at 5", cb.LastBlock)
+	}
+}
+
+func TestStoreFinalize(t *testing.T) {
+	store := openTestStore(t)
+	a := common.HexToAddress("0xa")
+	if err := store.AddContract(a, utils.StandardERC20, 1); err != nil {
+		t.Fatal(err)
+	}
+	saveBlocks(t, store, a, 10, 12)
+
+	if err := store.Finalize(11); err != nil {
+		t.Fatal(err)
+	}
+
+	got := eventBlocks(t, store, EventFilter{Contract: a})
+	if !got[10] || !got[11] || got[12] {
+		t.Fatalf("final flags by block = %v, want 10 and 11 final", got)
+	}
+	if n := len(eventBlocks(t, store, EventFilter{Contract: a, FinalOnly: true})); n != 2 {
+		t.Fatalf("FinalOnly returned %d events, want 2", n)
+	}
+
+	blocks, err := store.RecentBlocks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blocks) != 2 || blocks[0].Number != 12 || blocks[1].Number != 11 {
+		t.Fatalf("tracked blocks = %v, want 12 and 11", blocks)
+	}
+}
+
+func hasKey(m map[uint64]bool, k uint64) bool {
+	_, ok := m[k]
+	return ok
+}
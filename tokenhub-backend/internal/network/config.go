@@ -0,0 +1,139 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Network describes one chain the API serves.
+type Network struct {
+	Name        string   `yaml:"name" json:"name"`
+	ChainID     uint64   `yaml:"chainId" json:"chainId"`
+	RPCURLs     []string `yaml:"rpcUrls" json:"-"` // may carry provider API keys
+	ExplorerURL string   `yaml:"explorerUrl" json:"explorerUrl,omitempty"`
+	// SignerEnvPrefix selects the signer variables, e.g. HOLESKY reads
+	// HOLESKY_WALLET_KEYSTORE. Empty uses the unprefixed WALLET_* signers.
+	SignerEnvPrefix string `yaml:"signerEnvPrefix" json:"-"`
+	// MaxFeeGwei overrides GAS_MAX_FEE_GWEI, e.g. a tighter cap on mainnet.
+	MaxFeeGwei string `yaml:"maxFeeGwei" json:"-"`
+	// IndexerDBPath defaults to INDEXER_DB_PATH for the default network and
+	// tokenhub-<name>.db for the others.
+	IndexerDBPath string `yaml:"indexerDbPath" json:"-"`
+}
+
+// ChainIDBig returns the chain id as the *big.Int go-ethereum expects.
+func (n Network) ChainIDBig() *big.Int {
+	return new(big.Int).SetUint64(n.ChainID)
+}
+
+// TxURL links a transaction hash to the network's block explorer, or returns
+// "" when none is configured.
+func (n Network) TxURL(hash string) string {
+	if n.ExplorerURL == "" {
+		return ""
+	}
+	return strings.TrimRight(n.ExplorerURL, "/") + "/tx/" + hash
+}
+
+// Config is the network registry.
+type Config struct {
+	Default  string    `yaml:"default"`
+	Networks []Network `yaml:"networks"`
+}
+
+// Load reads a YAML registry from path. ${VAR} references are expanded from
+// the environment first, so RPC API keys can stay in .env.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg Config
+	dec := yaml.NewDecoder(strings.NewReader(os.ExpandEnv(string(data))))
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return &cfg, nil
+}
+
+// FromEnv loads the registry named by NETWORKS_CONFIG. Without it the API
+// serves Sepolia alone through SEPOLIA_RPC_URL, as before networks existed.
+func FromEnv() (*Config, error) {
+	if path := os.Getenv("NETWORKS_CONFIG"); path != "" {
+		return Load(path)
+	}
+
+	cfg := &Config{
+		Default: "sepolia",
+		Networks: []Network{{
+			Name:        "sepolia",
+			ChainID:     11155111,
+			RPCURLs:     []string{os.Getenv("SEPOLIA_RPC_URL")},
+			ExplorerURL: "https://sepolia.etherscan.io",
+		}},
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Get returns the network called name.
+func (c *Config) Get(name string) (Network, bool) {
+	for _, n := range c.Networks {
+		if n.Name == name {
+			return n, true
+		}
+	}
+	return Network{}, false
+}
+
+func (c *Config) validate() error {
+	if len(c.Networks) == 0 {
+		return errors.New("no networks configured")
+	}
+
+	seen := make(map[string]bool)
+	for i := range c.Networks {
+		n := &c.Networks[i]
+		if n.Name == "" {
+			return fmt.Errorf("network %d has no name", i)
+		}
+		if seen[n.Name] {
+			return fmt.Errorf("network %q is configured twice", n.Name)
+		}
+		seen[n.Name] = true
+		if n.ChainID == 0 {
+			return fmt.Errorf("network %q: chainId is required", n.Name)
+		}
+
+		urls := n.RPCURLs[:0]
+		for _, url := range n.RPCURLs {
+			if url = strings.TrimSpace(url); url != "" {
+				urls = append(urls, url)
+			}
+		}
+		n.RPCURLs = urls
+		if len(n.RPCURLs) == 0 {
+			return fmt.Errorf("network %q: at least one rpcUrl is required", n.Name)
+		}
+	}
+
+	if c.Default == "" {
+		c.Default = c.Networks[0].Name
+	}
+	if !seen[c.Default] {
+		return fmt.Errorf("default network %q is not configured", c.Default)
+	}
+	return nil
+}
@@ -0,0 +1,360 @@
+package nonce
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"tokenhub-api/internal/gas"
+	"tokenhub-api/internal/rpcpool"
+	"tokenhub-api/internal/signer"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ErrNotInFlight is returned by Replace when the nonce was already mined or
+// was never handed out by this manager.
+var ErrNotInFlight = errors.New("nonce is not pending")
+
+// account is the nonce bookkeeping of one sender.
+type account struct {
+	mu     sync.Mutex
+	synced bool
+	next   uint64
+	// released holds nonces that were handed out but never broadcast, or whose
+	// tx was dropped; they are reused before next so no gap is left behind.
+	released []uint64
+	// inflight maps broadcast nonces to their tx hash until they are mined.
+	inflight map[uint64]common.Hash
+}
+
+// Backend is the part of the RPC client the manager uses; *rpcpool.Pool
+// satisfies it.
+type Backend interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+}
+
+// FeeSource prices the transactions the manager sends itself; *gas.Strategy
+// satisfies it.
+type FeeSource interface {
+	Fees(ctx context.Context) (*gas.Fees, error)
+}
+
+var _ Backend = (*rpcpool.Pool)(nil)
+
+// Manager hands out nonces for the shared signers so concurrent requests never
+// reuse a pending nonce. The signers and fee source are used to fill nonce
+// gaps that would otherwise hold later transactions back.
+type Manager struct {
+	client  Backend
+	signers *signer.Registry
+	fees    FeeSource
+
+	mu       sync.Mutex
+	accounts map[common.Address]*account
+}
+
+func NewManager(client Backend, signers *signer.Registry, fees FeeSource) *Manager {
+	return &Manager{
+		client:   client,
+		signers:  signers,
+		fees:     fees,
+		accounts: make(map[common.Address]*account),
+	}
+}
+
+func (m *Manager) account(addr common.Address) *account {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	a, ok := m.accounts[addr]
+	if !ok {
+		a = &account{inflight: make(map[uint64]common.Hash)}
+		m.accounts[addr] = a
+	}
+	return a
+}
+
+// Transact sends one transaction built by send with a nonce reserved for
+// auth.From. send receives a copy of auth, so the shared options are never mutated.
+func (m *Manager) Transact(ctx context.Context, auth *bind.TransactOpts, send func(opts *bind.TransactOpts) (*types.Transaction, error)) (*types.Transaction, error) {
+	a := m.account(auth.From)
+
+	// Holding the account lock across send keeps nonces in submission order.
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.synced {
+		if err := m.resync(ctx, auth.From, a); err != nil {
+			return nil, err
+		}
+	}
+
+	n := a.take()
+	opts := *auth
+	opts.Nonce = new(big.Int).SetUint64(n)
+	if opts.Context == nil {
+		opts.Context = ctx
+	}
+
+	// The bindings sign right before broadcasting, so a signed transaction
+	// means the failure may have come from the send itself.
+	var signed *types.Transaction
+	opts.Signer = func(addr common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		tx, err := auth.Signer(addr, tx)
+		if err == nil {
+			signed = tx
+		}
+		return tx, err
+	}
+
+	tx, err := send(&opts)
+	switch {
+	case err == nil:
+	case signed == nil:
+		// Failed before the broadcast (e.g. gas estimation reverted): hand the nonce out again.
+		a.release(n)
+		return nil, err
+	case isAlreadyKnown(err):
+		// The node already holds this exact transaction.
+		tx = signed
+	case isNonceError(err):
+		// Our view of the account is stale, e.g. another process used the key.
+		log.Printf("Nonce %d for %s rejected (%v), resyncing", n, auth.From.Hex(), err)
+		a.synced = false
+		return nil, err
+	case isRejected(err):
+		// The node answered and refused the transaction, e.g. insufficient funds.
+		a.release(n)
+		return nil, err
+	default:
+		// The send may have reached the mempool before failing, e.g. on a
+		// timeout. Keep the nonce in flight; Reconcile releases it if the
+		// node never learns of the transaction.
+		log.Printf("Send of tx %s with nonce %d failed (%v), keeping it in flight", signed.Hash().Hex(), n, err)
+		a.inflight[n] = signed.Hash()
+		return nil, err
+	}
+
+	a.inflight[n] = tx.Hash()
+	return tx, nil
+}
+
+// Replace sends a transaction built by send at nonce n, which must still be
+// in flight for auth.From, replacing the pending transaction that holds it.
+func (m *Manager) Replace(ctx context.Context, auth *bind.TransactOpts, n uint64, send func(opts *bind.TransactOpts) (*types.Transaction, error)) (*types.Transaction, error) {
+	a := m.account(auth.From)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, ok := a.inflight[n]; !ok {
+		return nil, ErrNotInFlight
+	}
+
+	opts := *auth
+	opts.Nonce = new(big.Int).SetUint64(n)
+	if opts.Context == nil {
+		opts.Context = ctx
+	}
+
+	tx, err := send(&opts)
+	if err != nil {
+		return nil, err
+	}
+
+	a.inflight[n] = tx.Hash()
+	return tx, nil
+}
+
+// Reconcile drops mined nonces from the in-flight set and recycles the nonce
+// of any in-flight tx the node no longer knows about. A recycled nonce below
+// one that is still in flight is a gap the node will not mine past, so it is
+// filled with a zero-value self-transfer.
+func (m *Manager) Reconcile(ctx context.Context, addr common.Address) error {
+	a := m.account(addr)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	mined, err := m.client.NonceAt(ctx, addr, nil)
+	if err != nil {
+		return err
+	}
+
+	for n, hash := range a.inflight {
+		if n < mined {
+			delete(a.inflight, n)
+			continue
+		}
+		_, _, err := m.client.TransactionByHash(ctx, hash)
+		if errors.Is(err, ethereum.NotFound) {
+			log.Printf("Tx %s with nonce %d was dropped, nonce will be reused", hash.Hex(), n)
+			delete(a.inflight, n)
+			a.release(n)
+		}
+	}
+
+	var highest uint64
+	for n := range a.inflight {
+		highest = max(highest, n)
+	}
+
+	kept := a.released[:0]
+	for _, n := range a.released {
+		if n < mined {
+			continue
+		}
+		if len(a.inflight) > 0 && n < highest {
+			tx, err := m.fillGap(ctx, addr, n)
+			if err == nil {
+				log.Printf("Filled nonce gap %d for %s with %s", n, addr.Hex(), tx.Hash().Hex())
+				a.inflight[n] = tx.Hash()
+				continue
+			}
+			log.Printf("Failed to fill nonce gap %d for %s: %v", n, addr.Hex(), err)
+		}
+		kept = append(kept, n)
+	}
+	a.released = kept
+
+	if a.next < mined {
+		a.next = mined
+	}
+	return nil
+}
+
+// fillGap sends a zero-value self-transfer from addr at nonce n. The caller
+// holds the account lock.
+func (m *Manager) fillGap(ctx context.Context, addr common.Address, n uint64) (*types.Transaction, error) {
+	auth, ok := m.signers.ByAddress(addr)
+	if !ok {
+		return nil, fmt.Errorf("no signer for %s", addr.Hex())
+	}
+	chainID, err := m.client.ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	fees, err := m.fees.Fees(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error pricing transaction: %v", err)
+	}
+
+	unsigned := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     n,
+		GasTipCap: fees.GasTipCap,
+		GasFeeCap: fees.GasFeeCap,
+		Gas:       params.TxGas,
+		To:        &addr,
+		Value:     common.Big0,
+	})
+	signed, err := auth.Signer(addr, unsigned)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.client.SendTransaction(ctx, signed); err != nil {
+		return nil, err
+	}
+	return signed, nil
+}
+
+// Run reconciles every known account periodically until ctx is cancelled.
+func (m *Manager) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		m.mu.Lock()
+		addrs := make([]common.Address, 0, len(m.accounts))
+		for addr := range m.accounts {
+			addrs = append(addrs, addr)
+		}
+		m.mu.Unlock()
+
+		for _, addr := range addrs {
+			if err := m.Reconcile(ctx, addr); err != nil {
+				log.Printf("Nonce reconcile failed for %s: %v", addr.Hex(), err)
+			}
+		}
+	}
+}
+
+// resync reloads the next nonce from the node's pending state. Nonces between
+// the pending nonce and our highest in-flight one that have no tx are queued
+// for reuse, filling gaps. The caller holds a.mu.
+func (m *Manager) resync(ctx context.Context, addr common.Address, a *account) error {
+	pending, err := m.client.PendingNonceAt(ctx, addr)
+	if err != nil {
+		return err
+	}
+
+	next := pending
+	for n := range a.inflight {
+		if n >= next {
+			next = n + 1
+		}
+	}
+
+	a.released = a.released[:0]
+	for n := pending; n < next; n++ {
+		if _, ok := a.inflight[n]; !ok {
+			a.released = append(a.released, n)
+		}
+	}
+	a.next = next
+	a.synced = true
+	return nil
+}
+
+func (a *account) take() uint64 {
+	if len(a.released) > 0 {
+		n := a.released[0]
+		a.released = a.released[1:]
+		return n
+	}
+	n := a.next
+	a.next++
+	return n
+}
+
+func (a *account) release(n uint64) {
+	a.released = append(a.released, n)
+	sort.Slice(a.released, func(i, j int) bool { return a.released[i] < a.released[j] })
+}
+
+func isNonceError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "nonce too low") ||
+		strings.Contains(msg, "nonce too high") ||
+		strings.Contains(msg, "replacement transaction underpriced")
+}
+
+func isAlreadyKnown(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "already known")
+}
+
+// isRejected reports whether the node answered the send with a JSON-RPC
+// error, so the transaction was certainly not accepted.
+func isRejected(err error) bool {
+	var rpcErr rpc.Error
+	return errors.As(err, &rpcErr)
+}
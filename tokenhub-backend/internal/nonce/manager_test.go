@@ -0,0 +1,311 @@
+package nonce
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"tokenhub-api/internal/gas"
+	"tokenhub-api/internal/signer"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var testChainID = big.NewInt(11155111)
+
+// fakeBackend is an in-memory node: sent transactions are "known" until
+// dropped, and the mined and pending nonces are set by the test.
+type fakeBackend struct {
+	mu      sync.Mutex
+	mined   uint64
+	pending uint64
+	known   map[common.Hash]bool
+	sent    []*types.Transaction
+
+	// sendErr is returned by the next SendTransaction; deliver decides
+	// whether that transaction reaches the node anyway.
+	sendErr error
+	deliver bool
+}
+
+func newFakeBackend() *fakeBackend {
+	return &fakeBackend{known: make(map[common.Hash]bool)}
+}
+
+func (b *fakeBackend) ChainID(context.Context) (*big.Int, error) {
+	return testChainID, nil
+}
+
+func (b *fakeBackend) NonceAt(context.Context, common.Address, *big.Int) (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.mined, nil
+}
+
+func (b *fakeBackend) PendingNonceAt(context.Context, common.Address) (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.pending, nil
+}
+
+func (b *fakeBackend) TransactionByHash(_ context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.known[hash] {
+		return nil, false, ethereum.NotFound
+	}
+	return nil, true, nil
+}
+
+func (b *fakeBackend) SendTransaction(_ context.Context, tx *types.Transaction) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.sent = append(b.sent, tx)
+	err := b.sendErr
+	if err == nil || b.deliver {
+		b.known[tx.Hash()] = true
+	}
+	b.sendErr, b.deliver = nil, false
+	return err
+}
+
+func (b *fakeBackend) failNextSend(err error, deliver bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sendErr, b.deliver = err, deliver
+}
+
+func (b *fakeBackend) drop(hash common.Hash) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.known, hash)
+}
+
+type fixedFees struct{}
+
+func (fixedFees) Fees(context.Context) (*gas.Fees, error) {
+	return &gas.Fees{
+		BaseFee:   big.NewInt(params.GWei),
+		GasTipCap: big.NewInt(params.GWei),
+		GasFeeCap: big.NewInt(3 * params.GWei),
+	}, nil
+}
+
+// rpcError is a JSON-RPC error answered by the node.
+type rpcError string
+
+func (e rpcError) Error() string  { return string(e) }
+func (e rpcError) ErrorCode() int { return -32000 }
+
+func newTestManager(t *testing.T) (*Manager, *fakeBackend, *bind.TransactOpts) {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	signers := signer.NewRegistry(testChainID)
+	if err := signers.Add("main", signer.NewKeySigner(key)); err != nil {
+		t.Fatal(err)
+	}
+	backend := newFakeBackend()
+	return NewManager(backend, signers, fixedFees{}), backend, signers.Default()
+}
+
+// sendVia behaves like the generated bindings: it fails with estimateErr
+// before signing, otherwise signs and broadcasts a transaction at opts.Nonce.
+func sendVia(b *fakeBackend, estimateErr error) func(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		if estimateErr != nil {
+			return nil, estimateErr
+		}
+		to := common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+		unsigned := types.NewTx(&types.DynamicFeeTx{
+			ChainID:   testChainID,
+			Nonce:     opts.Nonce.Uint64(),
+			GasTipCap: big.NewInt(params.GWei),
+			GasFeeCap: big.NewInt(3 * params.GWei),
+			Gas:       params.TxGas,
+			To:        &to,
+			Value:     common.Big0,
+		})
+		signed, err := opts.Signer(opts.From, unsigned)
+		if err != nil {
+			return nil, err
+		}
+		if err := b.SendTransaction(opts.Context, signed); err != nil {
+			return nil, err
+		}
+		return signed, nil
+	}
+}
+
+func transact(t *testing.T, m *Manager, b *fakeBackend, auth *bind.TransactOpts) *types.Transaction {
+	t.Helper()
+	tx, err := m.Transact(context.Background(), auth, sendVia(b, nil))
+	if err != nil {
+		t.Fatalf("Transact: %v", err)
+	}
+	return tx
+}
+
+func expectNonce(t *testing.T, tx *types.Transaction, want uint64) {
+	t.Helper()
+	if tx.Nonce() != want {
+		t.Fatalf("nonce = %d, want %d", tx.Nonce(), want)
+	}
+}
+
+func TestTransactReleasesNonceOnEstimateFailure(t *testing.T) {
+	m, b, auth := newTestManager(t)
+
+	_, err := m.Transact(context.Background(), auth, sendVia(b, errors.New("execution reverted")))
+	if err == nil {
+		t.Fatal("expected estimate error")
+	}
+	expectNonce(t, transact(t, m, b, auth), 0)
+}
+
+func TestTransactReleasesNonceOnRejection(t *testing.T) {
+	m, b, auth := newTestManager(t)
+
+	b.failNextSend(rpcError("insufficient funds for gas * price + value"), false)
+	if _, err := m.Transact(context.Background(), auth, sendVia(b, nil)); err == nil {
+		t.Fatal("expected rejection")
+	}
+	expectNonce(t, transact(t, m, b, auth), 0)
+}
+
+func TestTransactAcceptsAlreadyKnown(t *testing.T) {
+	m, b, auth := newTestManager(t)
+
+	b.failNextSend(rpcError("already known"), true)
+	tx, err := m.Transact(context.Background(), auth, sendVia(b, nil))
+	if err != nil {
+		t.Fatalf("Transact: %v", err)
+	}
+	expectNonce(t, tx, 0)
+	if got := m.account(auth.From).inflight[0]; got != tx.Hash() {
+		t.Fatalf("in-flight hash = %s, want %s", got.Hex(), tx.Hash().Hex())
+	}
+	expectNonce(t, transact(t, m, b, auth), 1)
+}
+
+func TestTransactResyncsOnNonceTooLow(t *testing.T) {
+	m, b, auth := newTestManager(t)
+	b.pending = 5
+
+	expectNonce(t, transact(t, m, b, auth), 5)
+
+	b.pending = 8 // another process used 6 and 7
+	b.failNextSend(rpcError("nonce too low: next nonce 8, tx nonce 6"), false)
+	if _, err := m.Transact(context.Background(), auth, sendVia(b, nil)); err == nil {
+		t.Fatal("expected nonce error")
+	}
+	expectNonce(t, transact(t, m, b, auth), 8)
+}
+
+func TestAmbiguousSendStaysInFlightUntilReconciled(t *testing.T) {
+	m, b, auth := newTestManager(t)
+
+	// The send timed out but reached the node.
+	b.failNextSend(context.DeadlineExceeded, true)
+	if _, err := m.Transact(context.Background(), auth, sendVia(b, nil)); err == nil {
+		t.Fatal("expected send error")
+	}
+	expectNonce(t, transact(t, m, b, auth), 1)
+
+	if err := m.Reconcile(context.Background(), auth.From); err != nil {
+		t.Fatal(err)
+	}
+	expectNonce(t, transact(t, m, b, auth), 2)
+}
+
+func TestReconcileReleasesDroppedNonce(t *testing.T) {
+	m, b, auth := newTestManager(t)
+
+	// The send failed in transport and never reached the node.
+	b.failNextSend(errors.New("connection reset by peer"), false)
+	if _, err := m.Transact(context.Background(), auth, sendVia(b, nil)); err == nil {
+		t.Fatal("expected send error")
+	}
+	if _, ok := m.account(auth.From).inflight[0]; !ok {
+		t.Fatal("ambiguous send should stay in flight")
+	}
+
+	if err := m.Reconcile(context.Background(), auth.From); err != nil {
+		t.Fatal(err)
+	}
+	expectNonce(t, transact(t, m, b, auth), 0)
+}
+
+func TestReconcileDropsMinedNonces(t *testing.T) {
+	m, b, auth := newTestManager(t)
+
+	transact(t, m, b, auth)
+	transact(t, m, b, auth)
+	b.mined, b.pending = 2, 2
+
+	if err := m.Reconcile(context.Background(), auth.From); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(m.account(auth.From).inflight); n != 0 {
+		t.Fatalf("%d nonces still in flight after they were mined", n)
+	}
+	expectNonce(t, transact(t, m, b, auth), 2)
+}
+
+func TestReconcileFillsGapBelowInFlightNonce(t *testing.T) {
+	m, b, auth := newTestManager(t)
+
+	first := transact(t, m, b, auth)
+	transact(t, m, b, auth)
+	b.drop(first.Hash()) // evicted from the mempool; nonce 1 is now stuck
+
+	if err := m.Reconcile(context.Background(), auth.From); err != nil {
+		t.Fatal(err)
+	}
+
+	fill := b.sent[len(b.sent)-1]
+	expectNonce(t, fill, 0)
+	if fill.To() == nil || *fill.To() != auth.From || fill.Value().Sign() != 0 || fill.Gas() != params.TxGas {
+		t.Fatalf("gap filler is not a zero-value self-transfer: to=%v value=%s gas=%d", fill.To(), fill.Value(), fill.Gas())
+	}
+	from, err := types.Sender(types.LatestSignerForChainID(testChainID), fill)
+	if err != nil || from != auth.From {
+		t.Fatalf("gap filler sender = %s (%v), want %s", from.Hex(), err, auth.From.Hex())
+	}
+	if got := m.account(auth.From).inflight[0]; got != fill.Hash() {
+		t.Fatalf("nonce 0 in flight as %s, want the filler %s", got.Hex(), fill.Hash().Hex())
+	}
+	expectNonce(t, transact(t, m, b, auth), 2)
+}
+
+func TestReconcileKeepsGapReleasedWithoutSigner(t *testing.T) {
+	m, b, _ := newTestManager(t)
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stranger := signer.TransactOpts(signer.NewKeySigner(key), testChainID)
+
+	first := transact(t, m, b, stranger)
+	transact(t, m, b, stranger)
+	b.drop(first.Hash())
+	sent := len(b.sent)
+
+	if err := m.Reconcile(context.Background(), stranger.From); err != nil {
+		t.Fatal(err)
+	}
+	if len(b.sent) != sent {
+		t.Fatal("gap was filled without a registered signer")
+	}
+	expectNonce(t, transact(t, m, b, stranger), 0)
+}
@@ -0,0 +1,124 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	ErrNotFound     = errors.New("contract not found in registry")
+	ErrAliasTaken   = errors.New("alias is already in use")
+	ErrInvalidAlias = errors.New("alias must be 1-64 characters of a-z, 0-9, '-', '_' or '.', starting with a letter")
+)
+
+// aliasPattern keeps aliases distinct from hex addresses and safe in URLs.
+var aliasPattern = regexp.MustCompile(`^[a-z][a-z0-9._-]{0,63}$`)
+
+// WrongStandardError is returned when a reference resolves to a contract of
+// another standard than the route expects.
+type WrongStandardError struct {
+	Ref      string
+	Standard string
+	Want     string
+}
+
+func (e *WrongStandardError) Error() string {
+	return fmt.Sprintf("%s is an %s contract, not %s", e.Ref, e.Standard, e.Want)
+}
+
+// Registry is the view of a Store for one network.
+type Registry struct {
+	store   *Store
+	chainID uint64
+}
+
+func New(store *Store, chainID uint64) *Registry {
+	return &Registry{store: store, chainID: chainID}
+}
+
+// NormalizeAlias lower-cases alias and validates it. An empty alias is allowed.
+func NormalizeAlias(alias string) (string, error) {
+	alias = strings.ToLower(strings.TrimSpace(alias))
+	if alias != "" && !aliasPattern.MatchString(alias) {
+		return "", ErrInvalidAlias
+	}
+	return alias, nil
+}
+
+// CheckAlias validates alias and reports ErrAliasTaken if it is in use, so a
+// deploy can be refused before any gas is spent.
+func (r *Registry) CheckAlias(alias string) error {
+	alias, err := NormalizeAlias(alias)
+	if err != nil || alias == "" {
+		return err
+	}
+	_, ok, err := r.store.ByAlias(r.chainID, alias)
+	if err != nil {
+		return err
+	}
+	if ok {
+		return ErrAliasTaken
+	}
+	return nil
+}
+
+// Record stores a deployment on this network.
+func (r *Registry) Record(c Contract) error {
+	alias, err := NormalizeAlias(c.Alias)
+	if err != nil {
+		return err
+	}
+	c.Alias = alias
+	c.ChainID = r.chainID
+	if c.Labels == nil {
+		c.Labels = []string{}
+	}
+	return r.store.Add(c)
+}
+
+// Get looks a contract up by address or alias.
+func (r *Registry) Get(ref string) (*Contract, error) {
+	var (
+		c   *Contract
+		ok  bool
+		err error
+	)
+	if common.IsHexAddress(ref) {
+		c, ok, err = r.store.Get(r.chainID, common.HexToAddress(ref))
+	} else {
+		c, ok, err = r.store.ByAlias(r.chainID, strings.ToLower(strings.TrimSpace(ref)))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return c, nil
+}
+
+// List returns the deployments on this network, optionally of one standard.
+func (r *Registry) List(standard string) ([]Contract, error) {
+	return r.store.List(r.chainID, standard)
+}
+
+// Resolve turns a contract reference from a request into an address. Hex
+// addresses are used as given, whether or not they were deployed through
+// TokenHub; anything else is looked up as an alias of a standard contract.
+func (r *Registry) Resolve(ref, standard string) (common.Address, error) {
+	if common.IsHexAddress(ref) {
+		return common.HexToAddress(ref), nil
+	}
+	c, err := r.Get(ref)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if c.Standard != standard {
+		return common.Address{}, &WrongStandardError{Ref: ref, Standard: c.Standard, Want: standard}
+	}
+	return c.Address, nil
+}
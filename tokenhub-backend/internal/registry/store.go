@@ -0,0 +1,196 @@
+package registry
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	_ "modernc.org/sqlite"
+)
+
+// migrations are applied in order; PRAGMA user_version records how many have run.
+var migrations = []string{
+	`
+CREATE TABLE IF NOT EXISTS contracts (
+	chain_id     INTEGER NOT NULL,
+	address      TEXT NOT NULL,
+	standard     TEXT NOT NULL,
+	name         TEXT NOT NULL,
+	symbol       TEXT NOT NULL,
+	alias        TEXT,
+	labels       TEXT NOT NULL DEFAULT '[]',
+	deploy_tx    TEXT NOT NULL,
+	block_number INTEGER NOT NULL,
+	deployer     TEXT NOT NULL,
+	deployed_at  INTEGER NOT NULL,
+	PRIMARY KEY (chain_id, address)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS contracts_alias ON contracts (chain_id, alias);
+`,
+}
+
+// Contract is a deployment made through TokenHub.
+type Contract struct {
+	ChainID     uint64         `json:"chainId"`
+	Address     common.Address `json:"address"`
+	Standard    string         `json:"standard"`
+	Name        string         `json:"name"`
+	Symbol      string         `json:"symbol"`
+	Alias       string         `json:"alias,omitempty"`
+	Labels      []string       `json:"labels"`
+	DeployTx    string         `json:"deployTx"`
+	BlockNumber uint64         `json:"blockNumber"`
+	Deployer    common.Address `json:"deployer"`
+	DeployedAt  time.Time      `json:"deployedAt"`
+}
+
+// Store persists deployed contracts of every network, keyed by chain id.
+type Store struct {
+	db *sql.DB
+}
+
+// OpenStore opens (creating if needed) the SQLite database at path.
+func OpenStore(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	// SQLite allows a single writer; serialise access instead of retrying on SQLITE_BUSY.
+	db.SetMaxOpenConns(1)
+
+	if err := migrate(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("error migrating contract registry schema: %v", err)
+	}
+	return &Store{db: db}, nil
+}
+
+func migrate(db *sql.DB) error {
+	var version int
+	if err := db.QueryRow(`PRAGMA user_version`).Scan(&version); err != nil {
+		return err
+	}
+
+	for i := version; i < len(migrations); i++ {
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(migrations[i]); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %d: %v", i+1, err)
+		}
+		if _, err := tx.Exec(fmt.Sprintf(`PRAGMA user_version = %d`, i+1)); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Add records a deployment. It fails with ErrAliasTaken if another contract
+// on the same chain already uses the alias.
+func (s *Store) Add(c Contract) error {
+	labels, err := json.Marshal(c.Labels)
+	if err != nil {
+		return err
+	}
+	var alias interface{}
+	if c.Alias != "" {
+		alias = c.Alias
+	}
+
+	_, err = s.db.Exec(
+		`INSERT INTO contracts (chain_id, address, standard, name, symbol, alias, labels, deploy_tx, block_number, deployer, deployed_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		c.ChainID, c.Address.Hex(), c.Standard, c.Name, c.Symbol, alias, string(labels),
+		c.DeployTx, c.BlockNumber, c.Deployer.Hex(), c.DeployedAt.Unix(),
+	)
+	if err != nil && strings.Contains(err.Error(), "UNIQUE constraint failed: contracts.chain_id, contracts.alias") {
+		return ErrAliasTaken
+	}
+	return err
+}
+
+const selectContracts = `SELECT chain_id, address, standard, name, symbol, COALESCE(alias, ''), labels, deploy_tx, block_number, deployer, deployed_at FROM contracts`
+
+// Get returns the contract at address; ok is false when it was not deployed
+// through TokenHub.
+func (s *Store) Get(chainID uint64, address common.Address) (*Contract, bool, error) {
+	return s.queryOne(selectContracts+` WHERE chain_id = ? AND address = ?`, chainID, address.Hex())
+}
+
+// ByAlias returns the contract registered under alias.
+func (s *Store) ByAlias(chainID uint64, alias string) (*Contract, bool, error) {
+	return s.queryOne(selectContracts+` WHERE chain_id = ? AND alias = ?`, chainID, alias)
+}
+
+// List returns the contracts of a chain, newest first, optionally filtered
+// by standard.
+func (s *Store) List(chainID uint64, standard string) ([]Contract, error) {
+	query := selectContracts + ` WHERE chain_id = ?`
+	args := []interface{}{chainID}
+	if standard != "" {
+		query += ` AND standard = ?`
+		args = append(args, standard)
+	}
+	query += ` ORDER BY block_number DESC, address`
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	contracts := []Contract{}
+	for rows.Next() {
+		c, err := scanContract(rows)
+		if err != nil {
+			return nil, err
+		}
+		contracts = append(contracts, *c)
+	}
+	return contracts, rows.Err()
+}
+
+func (s *Store) queryOne(query string, args ...interface{}) (*Contract, bool, error) {
+	c, err := scanContract(s.db.QueryRow(query, args...))
+	if err == sql.ErrNoRows {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return c, true, nil
+}
+
+func scanContract(row interface{ Scan(...interface{}) error }) (*Contract, error) {
+	var (
+		c                 Contract
+		address, deployer string
+		labels            string
+		deployedAt        int64
+	)
+	if err := row.Scan(&c.ChainID, &address, &c.Standard, &c.Name, &c.Symbol, &c.Alias, &labels,
+		&c.DeployTx, &c.BlockNumber, &deployer, &deployedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(labels), &c.Labels); err != nil {
+		return nil, fmt.Errorf("contract %s: invalid labels: %v", address, err)
+	}
+	c.Address = common.HexToAddress(address)
+	c.Deployer = common.HexToAddress(deployer)
+	c.DeployedAt = time.Unix(deployedAt, 0).UTC()
+	return &c, nil
+}
@@ -1,25 +1,81 @@
 package router
 
 import (
+	"fmt"
 	"net/http"
+	"tokenhub-api/internal/gas"
 	"tokenhub-api/internal/handlers"
+	"tokenhub-api/internal/indexer"
 	"tokenhub-api/internal/middleware"
+	"tokenhub-api/internal/network"
+	"tokenhub-api/internal/registry"
+	"tokenhub-api/internal/rpcpool"
 	"tokenhub-api/internal/services"
+	"tokenhub-api/internal/signer"
+	"tokenhub-api/internal/txtracker"
 
 	"github.com/gorilla/mux"
 	"go.uber.org/zap"
 )
 
-func NewRouter(tokenSvc services.TokenService, nftSvc services.NFTService, logger *zap.Logger) http.Handler {
+// Network bundles the services bound to one configured network.
+type Network struct {
+	Config       network.Network
+	RPC          *rpcpool.Pool
+	Contracts    *registry.Registry
+	Token        services.TokenService
+	NFT          services.NFTService
+	Ownership    services.OwnershipService
+	Indexer      *indexer.Indexer
+	History      services.HistoryService
+	Transactions services.TransactionService
+	Estimate     services.EstimateService
+	Wallets      services.WalletService // nil when no HD wallet is configured
+	Tracker      *txtracker.Tracker
+	Gas          *gas.Strategy
+	Signers      *signer.Registry
+}
+
+// NewRouter serves every /api route once per network. The network is chosen
+// with the ?network= query parameter and falls back to cfg.Default.
+func NewRouter(cfg *network.Config, networks []Network, logger *zap.Logger) http.Handler {
 	r := mux.NewRouter()
 	r.Use(middleware.NewZapLoggerMiddleware(logger))
 
+	r.HandleFunc("/api/networks", handlers.HandleNetworks(cfg)).Methods("GET")
+
+	routes := make(map[string]http.Handler, len(networks))
+	for _, n := range networks {
+		routes[n.Config.Name] = networkRoutes(n)
+	}
+	r.PathPrefix("/api").HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		name := req.URL.Query().Get("network")
+		if name == "" {
+			name = cfg.Default
+		}
+		h, ok := routes[name]
+		if !ok {
+			http.Error(w, fmt.Sprintf("Unknown network %q", name), http.StatusBadRequest)
+			return
+		}
+		h.ServeHTTP(w, req)
+	})
+
+	return r
+}
+
+func networkRoutes(n Network) http.Handler {
+	tokenSvc, nftSvc, ownershipSvc := n.Token, n.NFT, n.Ownership
+	idx, historySvc, txSvc, estimateSvc, walletSvc := n.Indexer, n.History, n.Transactions, n.Estimate, n.Wallets
+	tracker, gasStrategy, signers, contractRegistry := n.Tracker, n.Gas, n.Signers, n.Contracts
+
+	r := mux.NewRouter()
 	api := r.PathPrefix("/api").Subrouter()
 
 	balance := api.PathPrefix("/balance").Subrouter()
-	balance.HandleFunc("/erc20", handlers.HandleERC20Balance(tokenSvc)).Methods("GET")
-	balance.HandleFunc("/erc721", handlers.HandleERC721Balance(nftSvc)).Methods("GET")
-	balance.HandleFunc("/erc1155", handlers.HandleERC1155Balance(nftSvc)).Methods("GET")
+	balance.HandleFunc("/erc20", handlers.HandleERC20Balance(tokenSvc, contractRegistry)).Methods("GET")
+	balance.HandleFunc("/erc721", handlers.HandleERC721Balance(nftSvc, contractRegistry)).Methods("GET")
+	balance.HandleFunc("/erc1155", handlers.HandleERC1155Balance(nftSvc, contractRegistry)).Methods("GET")
 
 	deploy := api.PathPrefix("/deploy").Subrouter()
 	deploy.HandleFunc("/erc20", handlers.DeployERC20Handler(tokenSvc)).Methods("POST")
@@ -27,14 +83,75 @@ func NewRouter(tokenSvc services.TokenService, nftSvc services.NFTService, logge
 	deploy.HandleFunc("/erc1155", handlers.DeployERC1155Handler(nftSvc)).Methods("POST")
 
 	mint := api.PathPrefix("/mint").Subrouter()
-	mint.HandleFunc("/erc20", handlers.MintERC20Handler(tokenSvc)).Methods("POST")
-	mint.HandleFunc("/erc721", handlers.MintERC721Handler(nftSvc)).Methods("POST")
-	mint.HandleFunc("/erc1155", handlers.MintERC1155Handler(nftSvc)).Methods("POST")
+	mint.HandleFunc("/erc20", handlers.MintERC20Handler(tokenSvc, contractRegistry)).Methods("POST")
+	mint.HandleFunc("/erc721", handlers.MintERC721Handler(nftSvc, contractRegistry)).Methods("POST")
+	mint.HandleFunc("/erc1155", handlers.MintERC1155Handler(nftSvc, contractRegistry)).Methods("POST")
 
 	burn := api.PathPrefix("/burn").Subrouter()
-	burn.HandleFunc("/erc20", handlers.BurnERC20Handler(tokenSvc)).Methods("POST")
-	burn.HandleFunc("/erc721", handlers.BurnERC721Handler(nftSvc)).Methods("POST")
-	burn.HandleFunc("/erc1155", handlers.BurnERC1155Handler(nftSvc)).Methods("POST")
+	burn.HandleFunc("/erc20", handlers.BurnERC20Handler(tokenSvc, contractRegistry)).Methods("POST")
+	burn.HandleFunc("/erc721", handlers.BurnERC721Handler(nftSvc, contractRegistry)).Methods("POST")
+	burn.HandleFunc("/erc1155", handlers.BurnERC1155Handler(nftSvc, contractRegistry)).Methods("POST")
+
+	transfer := api.PathPrefix("/transfer").Subrouter()
+	transfer.HandleFunc("/erc20", handlers.TransferERC20Handler(tokenSvc)).Methods("POST")
+	transfer.HandleFunc("/erc721", handlers.TransferERC721Handler(nftSvc)).Methods("POST")
+	transfer.HandleFunc("/erc1155", handlers.TransferERC1155Handler(nftSvc)).Methods("POST")
+	transfer.HandleFunc("/erc1155/batch", handlers.BatchTransferERC1155Handler(nftSvc)).Methods("POST")
+
+	transferFrom := api.PathPrefix("/transfer-from").Subrouter()
+	transferFrom.HandleFunc("/erc20", handlers.TransferFromERC20Handler(tokenSvc)).Methods("POST")
+
+	approve := api.PathPrefix("/approve").Subrouter()
+	approve.HandleFunc("/erc20", handlers.ApproveERC20Handler(tokenSvc)).Methods("POST")
+
+	revoke := api.PathPrefix("/revoke").Subrouter()
+	revoke.HandleFunc("/erc20", handlers.RevokeERC20Handler(tokenSvc)).Methods("POST")
+
+	allowance := api.PathPrefix("/allowance").Subrouter()
+	allowance.HandleFunc("/erc20", handlers.HandleERC20Allowance(tokenSvc)).Methods("GET")
+
+	approval := api.PathPrefix("/approval").Subrouter()
+	approval.HandleFunc("/erc721", handlers.HandleERC721Approval(nftSvc)).Methods("GET")
+	approval.HandleFunc("/erc721", handlers.ApproveERC721Handler(nftSvc)).Methods("POST")
+	approval.HandleFunc("/erc721/operator", handlers.SetApprovalForAllERC721Handler(nftSvc)).Methods("POST")
+
+	admin := api.PathPrefix("/admin").Subrouter()
+	admin.HandleFunc("/erc20/pause", handlers.PauseERC20Handler(tokenSvc)).Methods("POST")
+	admin.HandleFunc("/erc20/unpause", handlers.UnpauseERC20Handler(tokenSvc)).Methods("POST")
+	admin.HandleFunc("/erc20/rescue", handlers.RescueERC20Handler(tokenSvc)).Methods("POST")
+	admin.HandleFunc("/gas/profile", handlers.SetGasProfileHandler(gasStrategy)).Methods("POST")
+
+	contracts := api.PathPrefix("/contracts").Subrouter()
+	contracts.HandleFunc("", handlers.HandleContracts(contractRegistry)).Methods("GET")
+	contracts.HandleFunc("/{address}", handlers.HandleContract(contractRegistry)).Methods("GET")
+	contracts.HandleFunc("/{address}/owner", handlers.HandleContractOwner(ownershipSvc)).Methods("GET")
+	contracts.HandleFunc("/{address}/owner/transfer", handlers.TransferOwnershipHandler(ownershipSvc)).Methods("POST")
+	contracts.HandleFunc("/{address}/owner/renounce", handlers.RenounceOwnershipHandler(ownershipSvc)).Methods("POST")
+
+	indexed := api.PathPrefix("/indexer").Subrouter()
+	indexed.HandleFunc("/contracts", handlers.HandleIndexedContracts(idx)).Methods("GET")
+	indexed.HandleFunc("/contracts", handlers.TrackContractHandler(idx)).Methods("POST")
+
+	api.HandleFunc("/history", handlers.HandleHistory(historySvc)).Methods("GET")
+
+	txs := api.PathPrefix("/tx").Subrouter()
+	txs.HandleFunc("/{hash}", handlers.HandleTransactionStatus(tracker, n.Config)).Methods("GET")
+	txs.HandleFunc("/{hash}/speedup", handlers.SpeedUpTransactionHandler(txSvc)).Methods("POST")
+	txs.HandleFunc("/{hash}/cancel", handlers.CancelTransactionHandler(txSvc)).Methods("POST")
+
+	api.HandleFunc("/gas", handlers.HandleGasFees(gasStrategy)).Methods("GET")
+	api.HandleFunc("/signers", handlers.HandleSigners(signers)).Methods("GET")
+	api.HandleFunc("/rpc", handlers.HandleRPCStatus(n.RPC)).Methods("GET")
+
+	// Deposit wallets are only served when an HD wallet mnemonic is configured.
+	if walletSvc != nil {
+		wallets := api.PathPrefix("/wallets").Subrouter()
+		wallets.HandleFunc("", handlers.CreateWalletHandler(walletSvc)).Methods("POST")
+		wallets.HandleFunc("/{id}", handlers.HandleWallet(walletSvc)).Methods("GET")
+	}
+
+	estimate := api.PathPrefix("/estimate").Subrouter()
+	estimate.HandleFunc("/deploy/{standard}", handlers.HandleDeployEstimate(estimateSvc)).Methods("GET")
 
 	return r
 }
@@ -0,0 +1,371 @@
+package rpcpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	// ErrChainMismatch is returned when an endpoint serves a different chain
+	// than the one the pool was configured for.
+	ErrChainMismatch = errors.New("rpc endpoint serves a different chain")
+	// ErrNoHealthyEndpoint is returned when every endpoint failed its health check.
+	ErrNoHealthyEndpoint = errors.New("no healthy rpc endpoint")
+)
+
+var (
+	_ bind.ContractBackend = (*Pool)(nil)
+	_ bind.DeployBackend   = (*Pool)(nil)
+)
+
+// Config tunes the health checks and failover.
+type Config struct {
+	// MaxLag is how many blocks an endpoint may trail the highest head seen
+	// before it is taken out of rotation.
+	MaxLag uint64
+	// CheckInterval is how often every endpoint is probed.
+	CheckInterval time.Duration
+	// CallTimeout bounds each attempt of a read, so a hung endpoint fails
+	// over instead of holding the request until the client gives up.
+	CallTimeout time.Duration
+}
+
+func DefaultConfig() Config {
+	return Config{
+		MaxLag:        3,
+		CheckInterval: 10 * time.Second,
+		CallTimeout:   30 * time.Second,
+	}
+}
+
+// Pool spreads JSON-RPC traffic over several endpoints of one chain. Calls go
+// to the healthiest endpoint; reads that fail for transport reasons are
+// retried on the next one. Pool satisfies bind.ContractBackend, so it can be
+// passed to the generated bindings in place of an *ethclient.Client.
+type Pool struct {
+	chainID   *big.Int
+	cfg       Config
+	endpoints []*endpoint
+}
+
+type endpoint struct {
+	url    string
+	client *ethclient.Client
+
+	mu        sync.Mutex
+	verified  bool // chain id has been checked
+	healthy   bool
+	head      uint64
+	latency   time.Duration
+	lastErr   error
+	checkedAt time.Time
+}
+
+// EndpointStatus is the last health check result of one endpoint.
+type EndpointStatus struct {
+	URL       string `json:"url"` // scheme and host only; paths often carry API keys
+	Healthy   bool   `json:"healthy"`
+	Head      uint64 `json:"head,omitempty"`
+	LatencyMs int64  `json:"latencyMs,omitempty"`
+	Error     string `json:"error,omitempty"`
+	CheckedAt string `json:"checkedAt,omitempty"`
+}
+
+// Dial connects to every URL and runs a first health check. It fails if an
+// endpoint reports a chain id other than chainID, or if none is reachable.
+func Dial(urls []string, chainID *big.Int, cfg Config) (*Pool, error) {
+	if len(urls) == 0 {
+		return nil, errors.New("no rpc urls configured")
+	}
+
+	p := &Pool{chainID: chainID, cfg: cfg}
+	for _, u := range urls {
+		client, err := ethclient.Dial(u)
+		if err != nil {
+			p.Close()
+			return nil, fmt.Errorf("%s: %v", redact(u), err)
+		}
+		p.endpoints = append(p.endpoints, &endpoint{url: u, client: client})
+	}
+
+	if err := p.Check(context.Background()); err != nil {
+		p.Close()
+		return nil, err
+	}
+	return p, nil
+}
+
+// Run re-checks the endpoints every CheckInterval until ctx is cancelled.
+func (p *Pool) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.cfg.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := p.Check(ctx); err != nil {
+				log.Printf("rpc pool (chain %s): %v", p.chainID, err)
+			}
+		}
+	}
+}
+
+// Check probes every endpoint for its head block and latency and marks those
+// trailing the best head by more than MaxLag as unhealthy.
+func (p *Pool) Check(ctx context.Context) error {
+	type result struct {
+		head    uint64
+		latency time.Duration
+		err     error
+	}
+	results := make([]result, len(p.endpoints))
+
+	var wg sync.WaitGroup
+	for i, e := range p.endpoints {
+		wg.Add(1)
+		go func(i int, e *endpoint) {
+			defer wg.Done()
+			probeCtx, cancel := context.WithTimeout(ctx, p.cfg.CallTimeout)
+			defer cancel()
+			results[i].head, results[i].latency, results[i].err = p.probe(probeCtx, e)
+		}(i, e)
+	}
+	wg.Wait()
+
+	var best uint64
+	for _, r := range results {
+		if r.err == nil && r.head > best {
+			best = r.head
+		}
+	}
+
+	var errs []error
+	healthy := 0
+	now := time.Now()
+	for i, e := range p.endpoints {
+		r := results[i]
+		e.mu.Lock()
+		e.checkedAt = now
+		e.lastErr = r.err
+		if r.err == nil {
+			e.head = r.head
+			e.latency = r.latency
+			if lag := best - r.head; lag > p.cfg.MaxLag {
+				e.lastErr = fmt.Errorf("%d blocks behind", lag)
+			}
+		}
+		e.healthy = e.lastErr == nil
+		e.mu.Unlock()
+
+		if e.healthy {
+			healthy++
+		} else if errors.Is(r.err, ErrChainMismatch) {
+			errs = append(errs, fmt.Errorf("%s: %w", redact(e.url), r.err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	if healthy == 0 {
+		return fmt.Errorf("%w: %s", ErrNoHealthyEndpoint, p.describeErrors())
+	}
+	return nil
+}
+
+// probe verifies the chain id once, then times a block number request.
+func (p *Pool) probe(ctx context.Context, e *endpoint) (uint64, time.Duration, error) {
+	e.mu.Lock()
+	verified := e.verified
+	e.mu.Unlock()
+
+	if !verified {
+		id, err := e.client.ChainID(ctx)
+		if err != nil {
+			return 0, 0, err
+		}
+		if id.Cmp(p.chainID) != 0 {
+			return 0, 0, fmt.Errorf("%w: got %s, expected %s", ErrChainMismatch, id, p.chainID)
+		}
+		e.mu.Lock()
+		e.verified = true
+		e.mu.Unlock()
+	}
+
+	start := time.Now()
+	head, err := e.client.BlockNumber(ctx)
+	return head, time.Since(start), err
+}
+
+func (p *Pool) describeErrors() string {
+	parts := make([]string, 0, len(p.endpoints))
+	for _, e := range p.endpoints {
+		e.mu.Lock()
+		if e.lastErr != nil {
+			parts = append(parts, redact(e.url)+": "+redactErr(e.url, e.lastErr))
+		}
+		e.mu.Unlock()
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Status reports the last health check result of every endpoint.
+func (p *Pool) Status() []EndpointStatus {
+	statuses := make([]EndpointStatus, 0, len(p.endpoints))
+	for _, e := range p.endpoints {
+		e.mu.Lock()
+		status := EndpointStatus{
+			URL:       redact(e.url),
+			Healthy:   e.healthy,
+			Head:      e.head,
+			LatencyMs: e.latency.Milliseconds(),
+		}
+		if e.lastErr != nil {
+			status.Error = redactErr(e.url, e.lastErr)
+		}
+		if !e.checkedAt.IsZero() {
+			status.CheckedAt = e.checkedAt.UTC().Format(time.RFC3339)
+		}
+		e.mu.Unlock()
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// ordered returns the endpoints from healthiest to least healthy: healthy
+// ones by lag, then latency, followed by the rest as a last resort.
+func (p *Pool) ordered() []*endpoint {
+	type ranked struct {
+		e       *endpoint
+		healthy bool
+		head    uint64
+		latency time.Duration
+	}
+	ranks := make([]ranked, len(p.endpoints))
+	for i, e := range p.endpoints {
+		e.mu.Lock()
+		ranks[i] = ranked{e, e.healthy, e.head, e.latency}
+		e.mu.Unlock()
+	}
+
+	sort.SliceStable(ranks, func(i, j int) bool {
+		a, b := ranks[i], ranks[j]
+		if a.healthy != b.healthy {
+			return a.healthy
+		}
+		if !a.healthy {
+			return false
+		}
+		if a.head != b.head {
+			return a.head > b.head
+		}
+		return a.latency < b.latency
+	})
+
+	endpoints := make([]*endpoint, len(ranks))
+	for i, r := range ranks {
+		endpoints[i] = r.e
+	}
+	return endpoints
+}
+
+// fail takes e out of rotation until the next health check.
+func (e *endpoint) fail(err error) {
+	e.mu.Lock()
+	e.healthy = false
+	e.lastErr = err
+	e.mu.Unlock()
+}
+
+// read runs an idempotent call on the healthiest endpoint and retries it on
+// the next one while the failure looks like the endpoint's fault.
+func (p *Pool) read(ctx context.Context, call func(ctx context.Context, c *ethclient.Client) error) error {
+	var err error
+	for _, e := range p.ordered() {
+		attemptCtx, cancel := context.WithTimeout(ctx, p.cfg.CallTimeout)
+		err = call(attemptCtx, e.client)
+		cancel()
+		if err == nil || !retryable(ctx, err) {
+			return err
+		}
+		e.fail(err)
+	}
+	return err
+}
+
+// best returns the healthiest endpoint for calls that must not be repeated.
+func (p *Pool) best() *endpoint {
+	return p.ordered()[0]
+}
+
+// retryable reports whether err came from the endpoint rather than from the
+// request itself, so another endpoint may answer differently.
+func retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if errors.Is(err, ethereum.NotFound) {
+		return false
+	}
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		return false // revert data
+	}
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == 429 || httpErr.StatusCode >= 500
+	}
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		// The node answered; only errors that hint at a lagging, pruned or
+		// rate-limited node are worth another endpoint.
+		msg := strings.ToLower(rpcErr.Error())
+		return rpcErr.ErrorCode() == -32005 ||
+			strings.Contains(msg, "header not found") ||
+			strings.Contains(msg, "missing trie node") ||
+			strings.Contains(msg, "rate limit")
+	}
+	// Anything else failed in transport: refused connections, resets, timeouts.
+	return true
+}
+
+// redact strips everything but the scheme and host from an RPC URL.
+func redact(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return "<invalid url>"
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+// redactErr removes the full URL, which net/http quotes in its errors.
+func redactErr(rawURL string, err error) string {
+	return strings.TrimSpace(strings.ReplaceAll(err.Error(), rawURL, redact(rawURL)))
+}
+
+// ChainID returns the chain every endpoint was verified against.
+func (p *Pool) ChainID(ctx context.Context) (*big.Int, error) {
+	return new(big.Int).Set(p.chainID), nil
+}
+
+// Close closes every endpoint.
+func (p *Pool) Close() {
+	for _, e := range p.endpoints {
+		e.client.Close()
+	}
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+	"tokenhub-api/internal/indexer"
+	"tokenhub-api/internal/registry"
+	"tokenhub-api/internal/rpcpool"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// recordDeployment adds a confirmed deployment to the contract registry,
+// filling in the deploy tx and the number and time of its block, and has the
+// indexer follow the contract from that block. The contract is live whatever
+// happens here, so failures are logged and returned as a warning for the
+// response instead of an error. If a concurrent deploy claimed the alias
+// first, the contract is recorded without one. It returns the alias the
+// contract was recorded under.
+func recordDeployment(client *rpcpool.Pool, contracts *registry.Registry, idx *indexer.Indexer, tx *types.Transaction, c registry.Contract) (alias, warning string) {
+	err := recordContract(client, contracts, idx, tx, &c)
+	if errors.Is(err, registry.ErrAliasTaken) {
+		warning = fmt.Sprintf("alias %q was taken by another deploy; the contract was registered without an alias", c.Alias)
+		c.Alias = ""
+		err = contracts.Record(c)
+	}
+	if err != nil {
+		log.Printf("Failed to register %s %s: %v", c.Standard, c.Address.Hex(), err)
+		return "", fmt.Sprintf("the contract was deployed but not registered: %v", err)
+	}
+	return c.Alias, warning
+}
+
+func recordContract(client *rpcpool.Pool, contracts *registry.Registry, idx *indexer.Indexer, tx *types.Transaction, c *registry.Contract) error {
+	receipt, err := client.TransactionReceipt(context.Background(), tx.Hash())
+	if err != nil {
+		return fmt.Errorf("error fetching deploy receipt: %v", err)
+	}
+	header, err := client.HeaderByNumber(context.Background(), receipt.BlockNumber)
+	if err != nil {
+		return fmt.Errorf("error fetching deploy block: %v", err)
+	}
+
+	c.Alias, err = registry.NormalizeAlias(c.Alias)
+	if err != nil {
+		return err
+	}
+	c.DeployTx = tx.Hash().Hex()
+	c.BlockNumber = receipt.BlockNumber.Uint64()
+	c.DeployedAt = time.Unix(int64(header.Time), 0).UTC()
+	if err := idx.Track(c.Address, c.Standard, c.BlockNumber); err != nil {
+		return fmt.Errorf("error tracking contract: %v", err)
+	}
+	return contracts.Record(*c)
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	erc721 "tokenhub-api/contracts/ERC721"
+	"tokenhub-api/internal/indexer"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// erc721TokensOf replays the wallet's indexed Transfer events and returns the
+// ids it currently holds in ascending order. A token is held when the last
+// transfer involving the wallet sent it there. complete is false while the
+// indexer is still backfilling the contract.
+func erc721TokensOf(idx *indexer.Indexer, contract, wallet common.Address) ([]*big.Int, bool, error) {
+	events, complete, err := idx.Latest(context.Background(), indexer.EventFilter{
+		Contract: contract,
+		Wallet:   wallet,
+		Names:    []string{indexer.EventTransfer},
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	held := make(map[string]*big.Int)
+	for _, e := range events {
+		key := e.TokenID.String()
+		if e.To == wallet {
+			held[key] = e.TokenID
+		} else if e.From == wallet {
+			delete(held, key)
+		}
+	}
+
+	ids := make([]*big.Int, 0, len(held))
+	for _, id := range held {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i].Cmp(ids[j]) < 0 })
+	return ids, complete, nil
+}
+
+// erc721TokensByOwnerOf asks ownerOf for every id minted so far and returns
+// those held by wallet. It needs no index, but costs one call per token.
+func erc721TokensByOwnerOf(instance *erc721.Contracts, wallet common.Address) ([]*big.Int, error) {
+	currentTokenId, err := instance.GetCurrentTokenId(&bind.CallOpts{})
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []*big.Int
+	for i := int64(1); i <= currentTokenId.Int64(); i++ {
+		tokenId := big.NewInt(i)
+		owner, err := instance.OwnerOf(&bind.CallOpts{}, tokenId)
+		if err != nil {
+			// Burned tokens have no owner.
+			continue
+		}
+		if owner == wallet {
+			ids = append(ids, tokenId)
+		}
+	}
+	return ids, nil
+}
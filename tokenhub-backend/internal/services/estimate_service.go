@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	erc1155 "tokenhub-api/contracts/ERC1155"
+	erc20 "tokenhub-api/contracts/ERC20"
+	erc721 "tokenhub-api/contracts/ERC721"
+	"tokenhub-api/internal/gas"
+	"tokenhub-api/internal/rpcpool"
+	"tokenhub-api/internal/signer"
+	"tokenhub-api/internal/utils"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var ErrUnknownStandard = errors.New("unknown token standard")
+
+// weiDecimals is the number of decimals used to render wei amounts in ETH.
+const weiDecimals = 18
+
+// EstimateService prices operations before they are sent.
+type EstimateService interface {
+	EstimateDeploy(standard, name, symbol string, initialSupply *big.Int) (*DeployEstimateResponse, error)
+}
+
+type estimateService struct {
+	client  *rpcpool.Pool
+	signers *signer.Registry
+	gas     *gas.Strategy
+}
+
+func NewEstimateService(client *rpcpool.Pool, signers *signer.Registry, gasStrategy *gas.Strategy) EstimateService {
+	return &estimateService{client: client, signers: signers, gas: gasStrategy}
+}
+
+type DeployEstimateResponse struct {
+	Standard             string      `json:"standard"`
+	From                 string      `json:"from"`
+	EstimatedGas         uint64      `json:"estimatedGas"`
+	GasProfile           gas.Profile `json:"gasProfile"`
+	MaxFeePerGas         string      `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas string      `json:"maxPriorityFeePerGas"`
+	// EstimatedFeeEth is the expected cost at the current base fee; MaxFeeEth
+	// is the most the deployment could cost at the fee cap.
+	EstimatedFeeEth string `json:"estimatedFeeEth"`
+	MaxFeeEth       string `json:"maxFeeEth"`
+}
+
+// EstimateDeploy estimates a deployment by the default signer from the binding's creation bytecode
+// and ABI-encoded constructor arguments. initialSupply is in whole tokens and
+// only used for ERC20.
+func (s *estimateService) EstimateDeploy(standard, name, symbol string, initialSupply *big.Int) (*DeployEstimateResponse, error) {
+	var (
+		meta *bind.MetaData
+		args []interface{}
+	)
+	switch standard {
+	case utils.StandardERC20:
+		if initialSupply == nil {
+			initialSupply = new(big.Int)
+		}
+		meta = erc20.ContractsMetaData
+		args = []interface{}{name, symbol, new(big.Int).Mul(initialSupply, decimalsFactor(erc20DeployDecimals))}
+	case utils.StandardERC721:
+		meta = erc721.ContractsMetaData
+		args = []interface{}{name, symbol}
+	case utils.StandardERC1155:
+		meta = erc1155.ContractsMetaData
+		args = []interface{}{name, symbol}
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownStandard, standard)
+	}
+
+	data, err := deployData(meta, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	auth := s.signers.Default()
+	ctx := context.Background()
+	fees, err := s.gas.Fees(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error pricing deployment: %v", err)
+	}
+
+	gasLimit, err := s.client.EstimateGas(ctx, ethereum.CallMsg{From: auth.From, Data: data})
+	if err != nil {
+		if utils.IsRevert(err) {
+			return nil, fmt.Errorf("deployment would revert: %s", utils.RevertReason(err))
+		}
+		return nil, err
+	}
+
+	gasUsed := new(big.Int).SetUint64(gasLimit)
+	price := new(big.Int).Add(fees.BaseFee, fees.GasTipCap)
+	if price.Cmp(fees.GasFeeCap) > 0 {
+		price = fees.GasFeeCap
+	}
+
+	return &DeployEstimateResponse{
+		Standard:             standard,
+		From:                 auth.From.Hex(),
+		EstimatedGas:         gasLimit,
+		GasProfile:           fees.Profile,
+		MaxFeePerGas:         fees.GasFeeCap.String(),
+		MaxPriorityFeePerGas: fees.GasTipCap.String(),
+		EstimatedFeeEth:      formatTokenAmount(new(big.Int).Mul(gasUsed, price), weiDecimals),
+		MaxFeeEth:            formatTokenAmount(new(big.Int).Mul(gasUsed, fees.GasFeeCap), weiDecimals),
+	}, nil
+}
+
+// deployData is the creation bytecode followed by the packed constructor arguments.
+func deployData(meta *bind.MetaData, args ...interface{}) ([]byte, error) {
+	parsed, err := meta.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	input, err := parsed.Pack("", args...)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding constructor arguments: %v", err)
+	}
+	return append(common.FromHex(meta.Bin), input...), nil
+}
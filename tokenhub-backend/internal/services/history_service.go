@@ -0,0 +1,215 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+	erc20 "tokenhub-api/contracts/ERC20"
+	"tokenhub-api/internal/indexer"
+	"tokenhub-api/internal/rpcpool"
+	"tokenhub-api/internal/utils"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// History entry types derived from the indexed events.
+const (
+	HistoryTransfer = "transfer"
+	HistoryMint     = "mint"
+	HistoryBurn     = "burn"
+	HistoryApproval = "approval"
+)
+
+// historyEvents are the indexed events that make up a wallet's history. The
+// ERC1155 Mint event is left out because every mint also emits TransferSingle.
+var historyEvents = []string{
+	indexer.EventTransfer,
+	indexer.EventTransferSingle,
+	indexer.EventTransferBatch,
+	indexer.EventApproval,
+	indexer.EventApprovalForAll,
+}
+
+type HistoryService interface {
+	GetHistory(walletAddr, contractAddr, standard string, page, pageSize int) (*HistoryResponse, error)
+}
+
+type historyService struct {
+	client  *rpcpool.Pool
+	indexer *indexer.Indexer
+}
+
+func NewHistoryService(client *rpcpool.Pool, idx *indexer.Indexer) HistoryService {
+	return &historyService{client: client, indexer: idx}
+}
+
+type HistoryResponse struct {
+	WalletAddress   string `json:"walletAddress"`
+	ContractAddress string `json:"contractAddress"`
+	Standard        string `json:"standard"`
+	Page            int    `json:"page"`
+	PageSize        int    `json:"pageSize"`
+	Total           int    `json:"total"`
+	IndexedToBlock  uint64 `json:"indexedToBlock"`
+	// Indexing is true while the indexer has not reached the chain head for
+	// the contract, so the newest events may be missing.
+	Indexing bool          `json:"indexing"`
+	Items    []HistoryItem `json:"items"`
+}
+
+type HistoryItem struct {
+	Type            string `json:"type"`
+	Event           string `json:"event"`
+	Direction       string `json:"direction"`
+	From            string `json:"from,omitempty"`
+	To              string `json:"to,omitempty"`
+	Operator        string `json:"operator,omitempty"`
+	TokenID         string `json:"tokenId,omitempty"`
+	Amount          string `json:"amount,omitempty"`
+	Approved        *bool  `json:"approved,omitempty"`
+	BlockNumber     uint64 `json:"blockNumber"`
+	Timestamp       string `json:"timestamp"`
+	TransactionHash string `json:"transactionHash"`
+	LogIndex        uint   `json:"logIndex"`
+	Final           bool   `json:"final"`
+}
+
+// GetHistory returns the wallet's transfers, mints, burns and approvals on a
+// contract, newest first. The contract must already be tracked by the indexer;
+// Indexing reports whether it has yet to catch up with the chain head.
+func (s *historyService) GetHistory(walletAddr, contractAddr, standard string, page, pageSize int) (*HistoryResponse, error) {
+	wallet := common.HexToAddress(walletAddr)
+	contract := common.HexToAddress(contractAddr)
+	store := s.indexer.Store()
+
+	tracked, ok, err := store.Contract(contract)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, indexer.ErrNotTracked
+	}
+	if standard != "" && standard != tracked.Standard {
+		return nil, fmt.Errorf("contract %s is indexed as %s, not %s", contract.Hex(), tracked.Standard, standard)
+	}
+
+	head, err := s.client.BlockNumber(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &HistoryResponse{
+		WalletAddress:   wallet.Hex(),
+		ContractAddress: contract.Hex(),
+		Standard:        tracked.Standard,
+		Page:            page,
+		PageSize:        pageSize,
+		IndexedToBlock:  tracked.LastBlock,
+		Indexing:        tracked.LastBlock < head,
+		Items:           []HistoryItem{},
+	}
+
+	filter := indexer.EventFilter{
+		Contract:   contract,
+		Wallet:     wallet,
+		Names:      historyEvents,
+		Descending: true,
+		Limit:      pageSize,
+		Offset:     (page - 1) * pageSize,
+	}
+
+	resp.Total, err = store.CountEvents(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := store.Events(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	format := func(e indexer.Event) string { return bigString(e.Amount) }
+	if tracked.Standard == utils.StandardERC20 {
+		token, err := erc20.NewContracts(contract, s.client)
+		if err != nil {
+			return nil, err
+		}
+		decimals, err := token.Decimals(&bind.CallOpts{})
+		if err != nil {
+			return nil, err
+		}
+		format = func(e indexer.Event) string {
+			if e.Amount == nil {
+				return ""
+			}
+			return formatTokenAmount(e.Amount, decimals)
+		}
+	}
+
+	for _, e := range events {
+		resp.Items = append(resp.Items, historyItem(e, wallet, format(e)))
+	}
+	return resp, nil
+}
+
+func historyItem(e indexer.Event, wallet common.Address, amount string) HistoryItem {
+	item := HistoryItem{
+		Event:           e.Name,
+		From:            addressString(e.From),
+		To:              addressString(e.To),
+		Operator:        addressString(e.Operator),
+		TokenID:         bigString(e.TokenID),
+		Amount:          amount,
+		BlockNumber:     e.BlockNumber,
+		Timestamp:       e.BlockTime.Format(time.RFC3339),
+		TransactionHash: e.TxHash.Hex(),
+		LogIndex:        e.LogIndex,
+		Final:           e.Final,
+	}
+
+	switch e.Name {
+	case indexer.EventApproval, indexer.EventApprovalForAll:
+		item.Type = HistoryApproval
+		if e.Name == indexer.EventApprovalForAll {
+			approved := e.Data == "true"
+			item.Approved = &approved
+		}
+	default:
+		switch {
+		case e.From == (common.Address{}):
+			item.Type = HistoryMint
+		case e.To == (common.Address{}):
+			item.Type = HistoryBurn
+		default:
+			item.Type = HistoryTransfer
+		}
+	}
+
+	switch {
+	case e.From == wallet && e.To == wallet:
+		item.Direction = "self"
+	case e.From == wallet:
+		item.Direction = "out"
+	case e.To == wallet:
+		item.Direction = "in"
+	default:
+		item.Direction = "operator"
+	}
+	return item
+}
+
+func addressString(a common.Address) string {
+	if a == (common.Address{}) {
+		return ""
+	}
+	return a.Hex()
+}
+
+func bigString(v *big.Int) string {
+	if v == nil {
+		return ""
+	}
+	return v.String()
+}
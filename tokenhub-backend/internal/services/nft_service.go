@@ -2,42 +2,66 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"math/big"
+	"sort"
 	erc1155 "tokenhub-api/contracts/ERC1155"
 	erc721 "tokenhub-api/contracts/ERC721"
+	"tokenhub-api/internal/gas"
+	"tokenhub-api/internal/indexer"
+	"tokenhub-api/internal/nonce"
+	"tokenhub-api/internal/registry"
+	"tokenhub-api/internal/rpcpool"
+	"tokenhub-api/internal/signer"
+	"tokenhub-api/internal/txtracker"
+	"tokenhub-api/internal/utils"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 type NFTService interface {
 	GetERC721Details(walletAddr string, contractAddr string) (*NFTBalanceResponse, error)
-	DeployERC721(name, symbol string) (*DeployNFTResponse, error)
-	MintERC721(contractAddr common.Address, tokenURI string) (string, error)
-	BurnERC721(contractAddr common.Address, tokenId *big.Int) (string, error)
-
-	GetERC1155Details(walletAddr string, contractAddr string) (*NFTBalanceResponse, error)
-	DeployERC1155(name, symbol string) (*DeployNFTResponse, error)
-	MintERC1155(contractAddr common.Address, to string, amount *big.Int, tokenURI string) (string, error)
-	BurnERC1155(contractAddr common.Address, tokenId *big.Int, amount *big.Int) (string, error)
+	DeployERC721(signerName, name, symbol, alias string, labels []string) (*DeployNFTResponse, error)
+	MintERC721(signerName string, contractAddr common.Address, tokenURI string) (string, error)
+	BurnERC721(signerName string, contractAddr common.Address, tokenId *big.Int) (string, error)
+
+	GetERC1155Details(walletAddr string, contractAddr string, idRange *TokenIDRange) (*NFTBalanceResponse, error)
+	DeployERC1155(signerName, name, symbol, alias string, labels []string) (*DeployNFTResponse, error)
+	MintERC1155(signerName string, contractAddr common.Address, to string, amount *big.Int, tokenURI string) (string, error)
+	BurnERC1155(signerName string, contractAddr common.Address, tokenId *big.Int, amount *big.Int) (string, error)
+
+	TransferERC721(contractAddr common.Address, from, to string, tokenId *big.Int, safe bool, data []byte) (string, error)
+	ApproveERC721(contractAddr common.Address, to string, tokenId *big.Int) (string, error)
+	SetApprovalForAllERC721(contractAddr common.Address, operator string, approved bool) (string, error)
+	GetERC721Approval(contractAddr common.Address, tokenId *big.Int, owner, operator string) (*ERC721ApprovalResponse, error)
+
+	TransferERC1155(contractAddr common.Address, from, to string, tokenId *big.Int, amount *big.Int, data []byte) (string, error)
+	BatchTransferERC1155(contractAddr common.Address, from, to string, tokenIds []*big.Int, amounts []*big.Int, data []byte) (string, error)
+	SimulateDeployERC721(signerName, name, symbol string) (*SimulationResponse, error)
+	SimulateMintERC721(signerName string, contractAddr common.Address, tokenURI string) (*SimulationResponse, error)
+	SimulateBurnERC721(signerName string, contractAddr common.Address, tokenId *big.Int) (*SimulationResponse, error)
+	SimulateDeployERC1155(signerName, name, symbol string) (*SimulationResponse, error)
+	SimulateMintERC1155(signerName string, contractAddr common.Address, to string, amount *big.Int, tokenURI string) (*SimulationResponse, error)
+	SimulateBurnERC1155(signerName string, contractAddr common.Address, tokenId *big.Int, amount *big.Int) (*SimulationResponse, error)
 }
 
 type nftService struct {
-	client         *ethclient.Client
-	erc721         *erc721.Contracts
-	erc721Address  common.Address
-	erc1155        *erc1155.Contracts
-	erc1155Address common.Address
-	auth           *bind.TransactOpts
+	client    *rpcpool.Pool
+	contracts *registry.Registry
+	indexer   *indexer.Indexer
+	txSender
 }
 
-func NewNFTService(client *ethclient.Client, auth *bind.TransactOpts) NFTService {
+func NewNFTService(client *rpcpool.Pool, signers *signer.Registry, nonces *nonce.Manager, gasStrategy *gas.Strategy, tracker *txtracker.Tracker, contracts *registry.Registry, idx *indexer.Indexer) NFTService {
 	return &nftService{
-		client: client,
-		auth:   auth,
+		client:    client,
+		contracts: contracts,
+		indexer:   idx,
+		txSender:  txSender{signers: signers, nonces: nonces, gas: gasStrategy, tracker: tracker},
 	}
 }
 
@@ -55,6 +79,11 @@ type NFTItem struct {
 	Amount   string `json:"amount,omitempty"`
 }
 
+// GetERC721Details lists the wallet's tokens using ownership rebuilt from the
+// indexed Transfer events, so the cost is proportional to the wallet's
+// transfers rather than to the collection's total supply. Contracts the
+// indexer does not follow, or is still backfilling, are read from the chain
+// token by token instead.
 func (s *nftService) GetERC721Details(walletAddr string, contractAddr string) (*NFTBalanceResponse, error) {
 	addr := common.HexToAddress(walletAddr)
 	contract := common.HexToAddress(contractAddr)
@@ -74,28 +103,19 @@ func (s *nftService) GetERC721Details(walletAddr string, contractAddr string) (*
 		return nil, err
 	}
 
-	currentTokenId, err := erc721.GetCurrentTokenId(&bind.CallOpts{})
+	tokenIds, complete, err := erc721TokensOf(s.indexer, contract, addr)
+	if errors.Is(err, indexer.ErrNotTracked) || (err == nil && !complete) {
+		tokenIds, err = erc721TokensByOwnerOf(erc721, addr)
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	var nftItems []NFTItem
-	for i := int64(1); i <= currentTokenId.Int64(); i++ {
-		tokenId := big.NewInt(i)
-
-		owner, err := erc721.OwnerOf(&bind.CallOpts{}, tokenId)
-		if err != nil {
-			fmt.Println("Error getting token owner:", err)
-			continue
-		}
-
-		if owner != addr {
-			continue
-		}
-
+	for _, tokenId := range tokenIds {
 		tokenURI, err := erc721.TokenURI(&bind.CallOpts{}, tokenId)
 		if err != nil {
-			fmt.Println("Error getting Token URI:", err)
+			log.Println("Error getting Token URI:", err)
 			continue
 		}
 
@@ -123,13 +143,37 @@ type DeployNFTResponse struct {
 	TokenName   string `json:"tokenName"`
 	TokenSymbol string `json:"tokenSymbol"`
 	Address     string `json:"address"`
+	Alias       string `json:"alias,omitempty"`
+	// Warning explains why the contract is missing from the registry or was
+	// registered without the requested alias.
+	Warning string `json:"warning,omitempty"`
 }
 
-func (s *nftService) DeployERC721(name, symbol string) (*DeployNFTResponse, error) {
-	address, tx, instance, err := erc721.DeployContracts(s.auth, s.client, name, symbol)
+func (s *nftService) DeployERC721(signerName, name, symbol, alias string, labels []string) (*DeployNFTResponse, error) {
+	auth, err := s.signers.Get(signerName)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.contracts.CheckAlias(alias); err != nil {
+		return nil, err
+	}
+
+	var (
+		address  common.Address
+		instance *erc721.Contracts
+	)
+	tx, err := s.send(auth, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		var (
+			tx  *types.Transaction
+			err error
+		)
+		address, tx, instance, err = erc721.DeployContracts(opts, s.client, name, symbol)
+		return tx, err
+	})
 	if err != nil {
 		return nil, err
 	}
+	s.tracker.Track(tx, auth.From, "deploy", utils.StandardERC721, address)
 	log.Printf("ERC721 deployed at: %s (tx: %s)", address.Hex(), tx.Hash().Hex())
 
 	_, err = bind.WaitDeployed(context.Background(), s.client, tx)
@@ -147,43 +191,140 @@ func (s *nftService) DeployERC721(name, symbol string) (*DeployNFTResponse, erro
 		return nil, fmt.Errorf("error fetching symbol: %v", err)
 	}
 
-	s.erc721 = instance
-	s.erc721Address = address
-
-	return &DeployNFTResponse{
+	resp := &DeployNFTResponse{
 		TokenName:   tokenName,
 		TokenSymbol: tokenSymbol,
 		Address:     address.Hex(),
-	}, nil
+	}
+
+	resp.Alias, resp.Warning = recordDeployment(s.client, s.contracts, s.indexer, tx, registry.Contract{
+		Address:  address,
+		Standard: utils.StandardERC721,
+		Name:     tokenName,
+		Symbol:   tokenSymbol,
+		Alias:    alias,
+		Labels:   labels,
+		Deployer: auth.From,
+	})
+
+	return resp, nil
 }
 
-func (s *nftService) MintERC721(contractAddr common.Address, tokenURI string) (string, error) {
-	instance, err := erc721.NewContracts(contractAddr, s.client)
+func (s *nftService) MintERC721(signerName string, contractAddr common.Address, tokenURI string) (string, error) {
+	auth, err := s.signers.Get(signerName)
 	if err != nil {
 		return "", err
 	}
-	tx, err := instance.MintNFT(s.auth, tokenURI)
+
+	mint, err := s.mintERC721Tx(contractAddr, tokenURI)
 	if err != nil {
 		return "", err
 	}
+	tx, err := s.send(auth, mint)
+	if err != nil {
+		return "", err
+	}
+	s.tracker.Track(tx, auth.From, "mint", utils.StandardERC721, contractAddr)
 	log.Println("Minted ERC721 NFT with tx:", tx.Hash().Hex())
 	return tx.Hash().Hex(), nil
 }
 
-func (s *nftService) BurnERC721(contractAddr common.Address, tokenId *big.Int) (string, error) {
+func (s *nftService) mintERC721Tx(contractAddr common.Address, tokenURI string) (txFunc, error) {
 	instance, err := erc721.NewContracts(contractAddr, s.client)
+	if err != nil {
+		return nil, err
+	}
+	return func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return instance.MintNFT(opts, tokenURI)
+	}, nil
+}
+
+func (s *nftService) BurnERC721(signerName string, contractAddr common.Address, tokenId *big.Int) (string, error) {
+	auth, err := s.signers.Get(signerName)
+	if err != nil {
+		return "", err
+	}
+
+	burn, err := s.burnERC721Tx(contractAddr, tokenId)
 	if err != nil {
 		return "", err
 	}
-	tx, err := instance.BurnNFT(s.auth, tokenId)
+	tx, err := s.send(auth, burn)
 	if err != nil {
 		return "", err
 	}
+	s.tracker.Track(tx, auth.From, "burn", utils.StandardERC721, contractAddr)
 	log.Println("Burned ERC721 token:", tx.Hash().Hex())
 	return tx.Hash().Hex(), nil
 }
 
-func (s *nftService) GetERC1155Details(walletAddr string, contractAddr string) (*NFTBalanceResponse, error) {
+func (s *nftService) burnERC721Tx(contractAddr common.Address, tokenId *big.Int) (txFunc, error) {
+	instance, err := erc721.NewContracts(contractAddr, s.client)
+	if err != nil {
+		return nil, err
+	}
+	return func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return instance.BurnNFT(opts, tokenId)
+	}, nil
+}
+
+func (s *nftService) SimulateDeployERC721(signerName, name, symbol string) (*SimulationResponse, error) {
+	auth, err := s.signers.Get(signerName)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.simulate(s.client, auth, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		_, tx, _, err := erc721.DeployContracts(opts, s.client, name, symbol)
+		return tx, err
+	})
+}
+
+func (s *nftService) SimulateMintERC721(signerName string, contractAddr common.Address, tokenURI string) (*SimulationResponse, error) {
+	auth, err := s.signers.Get(signerName)
+	if err != nil {
+		return nil, err
+	}
+
+	mint, err := s.mintERC721Tx(contractAddr, tokenURI)
+	if err != nil {
+		return nil, err
+	}
+	return s.simulate(s.client, auth, mint)
+}
+
+func (s *nftService) SimulateBurnERC721(signerName string, contractAddr common.Address, tokenId *big.Int) (*SimulationResponse, error) {
+	auth, err := s.signers.Get(signerName)
+	if err != nil {
+		return nil, err
+	}
+
+	burn, err := s.burnERC721Tx(contractAddr, tokenId)
+	if err != nil {
+		return nil, err
+	}
+	return s.simulate(s.client, auth, burn)
+}
+
+// balanceOfBatchChunkSize bounds how many ids go into a single balanceOfBatch call.
+const balanceOfBatchChunkSize = 200
+
+// fallbackERC1155IDs is the id range checked when the ids cannot be
+// discovered from the indexer.
+var fallbackERC1155IDs = TokenIDRange{From: big.NewInt(0), To: big.NewInt(99)}
+
+// TokenIDRange is an inclusive range of ERC1155 token ids to query.
+type TokenIDRange struct {
+	From *big.Int
+	To   *big.Int
+}
+
+// GetERC1155Details lists every id in idRange that the wallet holds. When
+// idRange is nil the candidate ids are discovered from the indexed
+// TransferSingle and TransferBatch events that credited the wallet; contracts
+// the indexer does not follow, or is still backfilling, are checked for ids
+// 0-99 instead.
+func (s *nftService) GetERC1155Details(walletAddr string, contractAddr string, idRange *TokenIDRange) (*NFTBalanceResponse, error) {
 	addr := common.HexToAddress(walletAddr)
 	contract := common.HexToAddress(contractAddr)
 
@@ -205,42 +346,60 @@ func (s *nftService) GetERC1155Details(walletAddr string, contractAddr string) (
 		return nil, err
 	}
 
-	var nftItems []NFTItem
+	var ids []*big.Int
+	if idRange == nil {
+		var complete bool
+		ids, complete, err = receivedERC1155TokenIds(s.indexer, contract, addr)
+		if errors.Is(err, indexer.ErrNotTracked) || (err == nil && !complete) {
+			idRange, err = &fallbackERC1155IDs, nil
+		}
+		if err != nil {
+			log.Println("Error discovering token ids:", err)
+			return nil, err
+		}
+	}
+	if idRange != nil {
+		for id := new(big.Int).Set(idRange.From); id.Cmp(idRange.To) <= 0; id = new(big.Int).Add(id, big.NewInt(1)) {
+			ids = append(ids, id)
+		}
+	}
+
+	nftItems := []NFTItem{}
+
+	for start := 0; start < len(ids); start += balanceOfBatchChunkSize {
+		end := min(start+balanceOfBatchChunkSize, len(ids))
+		chunk := ids[start:end]
 
-	for tokenId := 0; tokenId < 100; tokenId++ {
-		id := big.NewInt(int64(tokenId))
+		accounts := make([]common.Address, len(chunk))
+		for i := range accounts {
+			accounts[i] = addr
+		}
 
-		balance, err := erc1155.BalanceOf(&bind.CallOpts{}, addr, id)
+		balances, err := erc1155.BalanceOfBatch(&bind.CallOpts{}, accounts, chunk)
 		if err != nil {
-			log.Println("Error fetching balance:", err)
-			continue
+			log.Println("Error fetching balances:", err)
+			return nil, err
 		}
 
-		if balance.Cmp(big.NewInt(0)) > 0 {
-			tokenURI, err := erc1155.Uri(&bind.CallOpts{}, id)
+		for i, balance := range balances {
+			if balance.Sign() <= 0 {
+				continue
+			}
+
+			tokenURI, err := erc1155.Uri(&bind.CallOpts{}, chunk[i])
 			if err != nil {
 				log.Println("Error fetching token URI:", err)
 				continue
 			}
 
 			nftItems = append(nftItems, NFTItem{
-				TokenID:  id.String(),
+				TokenID:  chunk[i].String(),
 				TokenURI: tokenURI,
 				Amount:   balance.String(),
 			})
 		}
 	}
 
-	if len(nftItems) == 0 {
-		return &NFTBalanceResponse{
-			TokenName:   name,
-			TokenSymbol: symbol,
-			Address:     contract.Hex(),
-			TotalTokens: "0",
-			NFTItems:    []NFTItem{},
-		}, nil
-	}
-
 	balance := big.NewInt(int64(len(nftItems)))
 
 	result := &NFTBalanceResponse{
@@ -254,11 +413,60 @@ func (s *nftService) GetERC1155Details(walletAddr string, contractAddr string) (
 	return result, nil
 }
 
-func (s *nftService) DeployERC1155(name, symbol string) (*DeployNFTResponse, error) {
-	address, tx, instance, err := erc1155.DeployContracts(s.auth, s.client, name, symbol)
+// receivedERC1155TokenIds returns, in ascending order, every id ever transferred
+// or minted to wallet according to the contract's indexed TransferSingle and
+// TransferBatch events. complete is false while the indexer is still
+// backfilling the contract.
+func receivedERC1155TokenIds(idx *indexer.Indexer, contract, wallet common.Address) ([]*big.Int, bool, error) {
+	events, complete, err := idx.Latest(context.Background(), indexer.EventFilter{
+		Contract: contract,
+		Wallet:   wallet,
+		Names:    []string{indexer.EventTransferSingle, indexer.EventTransferBatch},
+	})
 	if err != nil {
+		return nil, false, err
+	}
+
+	seen := make(map[string]*big.Int)
+	for _, e := range events {
+		if e.To == wallet {
+			seen[e.TokenID.String()] = e.TokenID
+		}
+	}
+
+	ids := make([]*big.Int, 0, len(seen))
+	for _, id := range seen {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i].Cmp(ids[j]) < 0 })
+	return ids, complete, nil
+}
+
+func (s *nftService) DeployERC1155(signerName, name, symbol, alias string, labels []string) (*DeployNFTResponse, error) {
+	auth, err := s.signers.Get(signerName)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.contracts.CheckAlias(alias); err != nil {
 		return nil, err
 	}
+
+	var (
+		address  common.Address
+		instance *erc1155.Contracts
+	)
+	tx, err := s.send(auth, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		var (
+			tx  *types.Transaction
+			err error
+		)
+		address, tx, instance, err = erc1155.DeployContracts(opts, s.client, name, symbol)
+		return tx, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.tracker.Track(tx, auth.From, "deploy", utils.StandardERC1155, address)
 	log.Printf("ERC1155 deployed at: %s (tx: %s)", address.Hex(), tx.Hash().Hex())
 
 	_, err = bind.WaitDeployed(context.Background(), s.client, tx)
@@ -276,39 +484,290 @@ func (s *nftService) DeployERC1155(name, symbol string) (*DeployNFTResponse, err
 		return nil, fmt.Errorf("error fetching symbol: %v", err)
 	}
 
-	s.erc1155 = instance
-	s.erc1155Address = address
-
-	return &DeployNFTResponse{
+	resp := &DeployNFTResponse{
 		TokenName:   tokenName,
 		TokenSymbol: tokenSymbol,
 		Address:     address.Hex(),
+	}
+
+	resp.Alias, resp.Warning = recordDeployment(s.client, s.contracts, s.indexer, tx, registry.Contract{
+		Address:  address,
+		Standard: utils.StandardERC1155,
+		Name:     tokenName,
+		Symbol:   tokenSymbol,
+		Alias:    alias,
+		Labels:   labels,
+		Deployer: auth.From,
+	})
+
+	return resp, nil
+}
+
+func (s *nftService) MintERC1155(signerName string, contractAddr common.Address, to string, amount *big.Int, tokenURI string) (string, error) {
+	auth, err := s.signers.Get(signerName)
+	if err != nil {
+		return "", err
+	}
+
+	mint, err := s.mintERC1155Tx(contractAddr, to, amount, tokenURI)
+	if err != nil {
+		return "", err
+	}
+	tx, err := s.send(auth, mint)
+	if err != nil {
+		return "", err
+	}
+	s.tracker.Track(tx, auth.From, "mint", utils.StandardERC1155, contractAddr)
+	log.Println("Minted ERC1155 with tx:", tx.Hash().Hex())
+	return tx.Hash().Hex(), nil
+}
+
+func (s *nftService) mintERC1155Tx(contractAddr common.Address, to string, amount *big.Int, tokenURI string) (txFunc, error) {
+	instance, err := erc1155.NewContracts(contractAddr, s.client)
+	if err != nil {
+		return nil, err
+	}
+	toAddr := common.HexToAddress(to)
+	return func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return instance.Mint(opts, toAddr, amount, tokenURI)
 	}, nil
 }
 
-func (s *nftService) MintERC1155(contractAddr common.Address, to string, amount *big.Int, tokenURI string) (string, error) {
+func (s *nftService) BurnERC1155(signerName string, contractAddr common.Address, tokenId *big.Int, amount *big.Int) (string, error) {
+	auth, err := s.signers.Get(signerName)
+	if err != nil {
+		return "", err
+	}
+
+	burn, err := s.burnERC1155Tx(contractAddr, tokenId, amount)
+	if err != nil {
+		return "", err
+	}
+	tx, err := s.send(auth, burn)
+	if err != nil {
+		return "", err
+	}
+	s.tracker.Track(tx, auth.From, "burn", utils.StandardERC1155, contractAddr)
+	log.Println("Burned ERC1155 token:", tx.Hash().Hex())
+	return tx.Hash().Hex(), nil
+}
+
+func (s *nftService) burnERC1155Tx(contractAddr common.Address, tokenId *big.Int, amount *big.Int) (txFunc, error) {
 	instance, err := erc1155.NewContracts(contractAddr, s.client)
+	if err != nil {
+		return nil, err
+	}
+	return func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return instance.Burn(opts, opts.From, tokenId, amount)
+	}, nil
+}
+
+func (s *nftService) SimulateDeployERC1155(signerName, name, symbol string) (*SimulationResponse, error) {
+	auth, err := s.signers.Get(signerName)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.simulate(s.client, auth, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		_, tx, _, err := erc1155.DeployContracts(opts, s.client, name, symbol)
+		return tx, err
+	})
+}
+
+func (s *nftService) SimulateMintERC1155(signerName string, contractAddr common.Address, to string, amount *big.Int, tokenURI string) (*SimulationResponse, error) {
+	auth, err := s.signers.Get(signerName)
+	if err != nil {
+		return nil, err
+	}
+
+	mint, err := s.mintERC1155Tx(contractAddr, to, amount, tokenURI)
+	if err != nil {
+		return nil, err
+	}
+	return s.simulate(s.client, auth, mint)
+}
+
+func (s *nftService) SimulateBurnERC1155(signerName string, contractAddr common.Address, tokenId *big.Int, amount *big.Int) (*SimulationResponse, error) {
+	auth, err := s.signers.Get(signerName)
+	if err != nil {
+		return nil, err
+	}
+
+	burn, err := s.burnERC1155Tx(contractAddr, tokenId, amount)
+	if err != nil {
+		return nil, err
+	}
+	return s.simulate(s.client, auth, burn)
+}
+
+// TransferERC721 moves tokenId from `from` (the server wallet when empty) to `to`.
+// With safe set it uses safeTransferFrom, passing data to the receiver hook when given.
+func (s *nftService) TransferERC721(contractAddr common.Address, from, to string, tokenId *big.Int, safe bool, data []byte) (string, error) {
+	auth := s.signers.Default()
+	instance, err := erc721.NewContracts(contractAddr, s.client)
 	if err != nil {
 		return "", err
 	}
+
+	fromAddr := auth.From
+	if from != "" {
+		fromAddr = common.HexToAddress(from)
+	}
 	toAddr := common.HexToAddress(to)
-	tx, err := instance.Mint(s.auth, toAddr, amount, tokenURI)
+
+	tx, err := s.send(auth, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		switch {
+		case safe && len(data) > 0:
+			return instance.SafeTransferFrom0(opts, fromAddr, toAddr, tokenId, data)
+		case safe:
+			return instance.SafeTransferFrom(opts, fromAddr, toAddr, tokenId)
+		default:
+			return instance.TransferFrom(opts, fromAddr, toAddr, tokenId)
+		}
+	})
 	if err != nil {
 		return "", err
 	}
-	log.Println("Minted ERC1155 with tx:", tx.Hash().Hex())
+	s.tracker.Track(tx, auth.From, "transfer", utils.StandardERC721, contractAddr)
+	log.Println("Transferred ERC721 token:", tx.Hash().Hex())
 	return tx.Hash().Hex(), nil
 }
 
-func (s *nftService) BurnERC1155(contractAddr common.Address, tokenId *big.Int, amount *big.Int) (string, error) {
+func (s *nftService) ApproveERC721(contractAddr common.Address, to string, tokenId *big.Int) (string, error) {
+	auth := s.signers.Default()
+	instance, err := erc721.NewContracts(contractAddr, s.client)
+	if err != nil {
+		return "", err
+	}
+	tx, err := s.send(auth, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return instance.Approve(opts, common.HexToAddress(to), tokenId)
+	})
+	if err != nil {
+		return "", err
+	}
+	s.tracker.Track(tx, auth.From, "approve", utils.StandardERC721, contractAddr)
+	log.Println("Approved ERC721 token:", tx.Hash().Hex())
+	return tx.Hash().Hex(), nil
+}
+
+func (s *nftService) SetApprovalForAllERC721(contractAddr common.Address, operator string, approved bool) (string, error) {
+	auth := s.signers.Default()
+	instance, err := erc721.NewContracts(contractAddr, s.client)
+	if err != nil {
+		return "", err
+	}
+	tx, err := s.send(auth, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return instance.SetApprovalForAll(opts, common.HexToAddress(operator), approved)
+	})
+	if err != nil {
+		return "", err
+	}
+	s.tracker.Track(tx, auth.From, "setApprovalForAll", utils.StandardERC721, contractAddr)
+	log.Println("Set ERC721 operator approval:", tx.Hash().Hex())
+	return tx.Hash().Hex(), nil
+}
+
+type ERC721ApprovalResponse struct {
+	Address  string `json:"address"`
+	TokenID  string `json:"tokenId"`
+	Owner    string `json:"owner"`
+	Approved string `json:"approved"`
+	Operator string `json:"operator,omitempty"`
+	// IsApprovedForAll is only set when an operator was given.
+	IsApprovedForAll *bool `json:"isApprovedForAll,omitempty"`
+}
+
+// GetERC721Approval reports the per-token approval for tokenId and whether
+// operator may manage all of the owner's tokens. The owner defaults to the
+// token's current holder.
+func (s *nftService) GetERC721Approval(contractAddr common.Address, tokenId *big.Int, owner, operator string) (*ERC721ApprovalResponse, error) {
+	instance, err := erc721.NewContracts(contractAddr, s.client)
+	if err != nil {
+		return nil, err
+	}
+
+	approved, err := instance.GetApproved(&bind.CallOpts{}, tokenId)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching approval: %v", err)
+	}
+
+	ownerAddr := common.HexToAddress(owner)
+	if owner == "" {
+		ownerAddr, err = instance.OwnerOf(&bind.CallOpts{}, tokenId)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching token owner: %v", err)
+		}
+	}
+
+	result := &ERC721ApprovalResponse{
+		Address:  contractAddr.Hex(),
+		TokenID:  tokenId.String(),
+		Owner:    ownerAddr.Hex(),
+		Approved: approved.Hex(),
+	}
+
+	if operator != "" {
+		operatorAddr := common.HexToAddress(operator)
+		isApproved, err := instance.IsApprovedForAll(&bind.CallOpts{}, ownerAddr, operatorAddr)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching operator approval: %v", err)
+		}
+		result.Operator = operatorAddr.Hex()
+		result.IsApprovedForAll = &isApproved
+	}
+
+	return result, nil
+}
+
+// TransferERC1155 sends amount of tokenId from `from` (the server wallet when empty) to `to`.
+func (s *nftService) TransferERC1155(contractAddr common.Address, from, to string, tokenId *big.Int, amount *big.Int, data []byte) (string, error) {
+	auth := s.signers.Default()
 	instance, err := erc1155.NewContracts(contractAddr, s.client)
 	if err != nil {
 		return "", err
 	}
-	tx, err := instance.Burn(s.auth, s.auth.From, tokenId, amount)
+
+	fromAddr := auth.From
+	if from != "" {
+		fromAddr = common.HexToAddress(from)
+	}
+
+	tx, err := s.send(auth, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return instance.SafeTransferFrom(opts, fromAddr, common.HexToAddress(to), tokenId, amount, data)
+	})
 	if err != nil {
 		return "", err
 	}
-	log.Println("Burned ERC1155 token:", tx.Hash().Hex())
+	s.tracker.Track(tx, auth.From, "transfer", utils.StandardERC1155, contractAddr)
+	log.Println("Transferred ERC1155 token:", tx.Hash().Hex())
+	return tx.Hash().Hex(), nil
+}
+
+// BatchTransferERC1155 sends several token ids in one transaction. tokenIds and
+// amounts are parallel arrays and must have the same length.
+func (s *nftService) BatchTransferERC1155(contractAddr common.Address, from, to string, tokenIds []*big.Int, amounts []*big.Int, data []byte) (string, error) {
+	auth := s.signers.Default()
+	if len(tokenIds) == 0 || len(tokenIds) != len(amounts) {
+		return "", fmt.Errorf("tokenIds and amounts must be non-empty and of equal length (got %d and %d)", len(tokenIds), len(amounts))
+	}
+
+	instance, err := erc1155.NewContracts(contractAddr, s.client)
+	if err != nil {
+		return "", err
+	}
+
+	fromAddr := auth.From
+	if from != "" {
+		fromAddr = common.HexToAddress(from)
+	}
+
+	tx, err := s.send(auth, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return instance.SafeBatchTransferFrom(opts, fromAddr, common.HexToAddress(to), tokenIds, amounts, data)
+	})
+	if err != nil {
+		return "", err
+	}
+	s.tracker.Track(tx, auth.From, "batchTransfer", utils.StandardERC1155, contractAddr)
+	log.Println("Batch transferred ERC1155 tokens:", tx.Hash().Hex())
 	return tx.Hash().Hex(), nil
 }
@@ -0,0 +1,220 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	erc1155 "tokenhub-api/contracts/ERC1155"
+	erc20 "tokenhub-api/contracts/ERC20"
+	erc721 "tokenhub-api/contracts/ERC721"
+	"tokenhub-api/internal/gas"
+	"tokenhub-api/internal/nonce"
+	"tokenhub-api/internal/rpcpool"
+	"tokenhub-api/internal/signer"
+	"tokenhub-api/internal/txtracker"
+	"tokenhub-api/internal/utils"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ERC-165 interface identifiers used to tell the NFT standards apart.
+var (
+	erc721InterfaceID  = [4]byte{0x80, 0xac, 0x58, 0xcd}
+	erc1155InterfaceID = [4]byte{0xd9, 0xb6, 0x7a, 0x26}
+)
+
+var (
+	ErrNoContract = errors.New("no contract deployed at address")
+	// ErrOwnershipNotTransferable is returned for the TokenHub ERC721 contract,
+	// which exposes owner() but not transferOwnership/renounceOwnership.
+	ErrOwnershipNotTransferable = errors.New("contract does not support ownership transfer")
+)
+
+type OwnershipService interface {
+	GetOwner(contractAddr common.Address) (*OwnershipResponse, error)
+	TransferOwnership(contractAddr common.Address, newOwner string) (*OwnershipTxResponse, error)
+	RenounceOwnership(contractAddr common.Address) (*OwnershipTxResponse, error)
+}
+
+type ownershipService struct {
+	client *rpcpool.Pool
+	txSender
+}
+
+func NewOwnershipService(client *rpcpool.Pool, signers *signer.Registry, nonces *nonce.Manager, gasStrategy *gas.Strategy, tracker *txtracker.Tracker) OwnershipService {
+	return &ownershipService{
+		client:   client,
+		txSender: txSender{signers: signers, nonces: nonces, gas: gasStrategy, tracker: tracker},
+	}
+}
+
+// ownable is the owner() getter shared by all three generated bindings.
+type ownable interface {
+	Owner(opts *bind.CallOpts) (common.Address, error)
+}
+
+// transferableOwnable is the full Ownable surface, present on the ERC20 and ERC1155 bindings.
+type transferableOwnable interface {
+	ownable
+	TransferOwnership(opts *bind.TransactOpts, newOwner common.Address) (*types.Transaction, error)
+	RenounceOwnership(opts *bind.TransactOpts) (*types.Transaction, error)
+}
+
+type OwnershipResponse struct {
+	Address  string `json:"address"`
+	Standard string `json:"standard"`
+	Owner    string `json:"owner"`
+}
+
+type OwnershipTxResponse struct {
+	TransactionHash string `json:"transactionHash"`
+	Address         string `json:"address"`
+	Standard        string `json:"standard"`
+	PreviousOwner   string `json:"previousOwner"`
+	NewOwner        string `json:"newOwner"`
+}
+
+func (s *ownershipService) GetOwner(contractAddr common.Address) (*OwnershipResponse, error) {
+	standard, instance, err := s.bindOwnable(contractAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := instance.Owner(&bind.CallOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching owner: %v", err)
+	}
+
+	return &OwnershipResponse{
+		Address:  contractAddr.Hex(),
+		Standard: standard,
+		Owner:    owner.Hex(),
+	}, nil
+}
+
+func (s *ownershipService) TransferOwnership(contractAddr common.Address, newOwner string) (*OwnershipTxResponse, error) {
+	auth := s.signers.Default()
+	standard, bound, err := s.bindOwnable(contractAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	instance, ok := bound.(transferableOwnable)
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", standard, ErrOwnershipNotTransferable)
+	}
+
+	previousOwner, err := instance.Owner(&bind.CallOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching owner: %v", err)
+	}
+
+	newOwnerAddr := common.HexToAddress(newOwner)
+	tx, err := s.send(auth, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return instance.TransferOwnership(opts, newOwnerAddr)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.tracker.Track(tx, auth.From, "transferOwnership", standard, contractAddr)
+	log.Printf("Transferred %s ownership of %s to %s (tx: %s)", standard, contractAddr.Hex(), newOwnerAddr.Hex(), tx.Hash().Hex())
+
+	return &OwnershipTxResponse{
+		TransactionHash: tx.Hash().Hex(),
+		Address:         contractAddr.Hex(),
+		Standard:        standard,
+		PreviousOwner:   previousOwner.Hex(),
+		NewOwner:        newOwnerAddr.Hex(),
+	}, nil
+}
+
+func (s *ownershipService) RenounceOwnership(contractAddr common.Address) (*OwnershipTxResponse, error) {
+	auth := s.signers.Default()
+	standard, bound, err := s.bindOwnable(contractAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	instance, ok := bound.(transferableOwnable)
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", standard, ErrOwnershipNotTransferable)
+	}
+
+	previousOwner, err := instance.Owner(&bind.CallOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching owner: %v", err)
+	}
+
+	tx, err := s.send(auth, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return instance.RenounceOwnership(opts)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.tracker.Track(tx, auth.From, "renounceOwnership", standard, contractAddr)
+	log.Printf("Renounced %s ownership of %s (tx: %s)", standard, contractAddr.Hex(), tx.Hash().Hex())
+
+	return &OwnershipTxResponse{
+		TransactionHash: tx.Hash().Hex(),
+		Address:         contractAddr.Hex(),
+		Standard:        standard,
+		PreviousOwner:   previousOwner.Hex(),
+		NewOwner:        common.Address{}.Hex(),
+	}, nil
+}
+
+// bindOwnable detects the contract standard and returns the matching binding.
+func (s *ownershipService) bindOwnable(contractAddr common.Address) (string, ownable, error) {
+	standard, err := DetectStandard(s.client, contractAddr)
+	if err != nil {
+		return "", nil, err
+	}
+
+	switch standard {
+	case utils.StandardERC721:
+		instance, err := erc721.NewContracts(contractAddr, s.client)
+		return standard, instance, err
+	case utils.StandardERC1155:
+		instance, err := erc1155.NewContracts(contractAddr, s.client)
+		return standard, instance, err
+	default:
+		instance, err := erc20.NewContracts(contractAddr, s.client)
+		return standard, instance, err
+	}
+}
+
+// DetectStandard identifies a TokenHub contract as ERC721 or ERC1155 through
+// ERC-165, falling back to ERC20 when the contract answers decimals().
+func DetectStandard(client *rpcpool.Pool, contractAddr common.Address) (string, error) {
+	code, err := client.CodeAt(context.Background(), contractAddr, nil)
+	if err != nil {
+		return "", err
+	}
+	if len(code) == 0 {
+		return "", ErrNoContract
+	}
+
+	// ERC721 and ERC1155 share the supportsInterface ABI, so either binding can ask.
+	probe, err := erc721.NewContracts(contractAddr, client)
+	if err != nil {
+		return "", err
+	}
+	if ok, err := probe.SupportsInterface(&bind.CallOpts{}, erc721InterfaceID); err == nil && ok {
+		return utils.StandardERC721, nil
+	}
+	if ok, err := probe.SupportsInterface(&bind.CallOpts{}, erc1155InterfaceID); err == nil && ok {
+		return utils.StandardERC1155, nil
+	}
+
+	token, err := erc20.NewContracts(contractAddr, client)
+	if err != nil {
+		return "", err
+	}
+	if _, err := token.Decimals(&bind.CallOpts{}); err != nil {
+		return "", fmt.Errorf("unable to detect token standard for %s", contractAddr.Hex())
+	}
+	return utils.StandardERC20, nil
+}
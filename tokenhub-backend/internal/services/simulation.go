@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"tokenhub-api/internal/rpcpool"
+	"tokenhub-api/internal/utils"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// txFunc builds, and unless opts.NoSend is set broadcasts, one transaction.
+type txFunc func(opts *bind.TransactOpts) (*types.Transaction, error)
+
+// simulationGasLimit is only used to build the unsent transaction; the real
+// estimate comes from eth_estimateGas.
+const simulationGasLimit = 30_000_000
+
+// SimulationResponse is the outcome of a dry run. Nothing is broadcast.
+type SimulationResponse struct {
+	DryRun               bool   `json:"dryRun"`
+	Success              bool   `json:"success"`
+	From                 string `json:"from"`
+	To                   string `json:"to,omitempty"`
+	EstimatedGas         uint64 `json:"estimatedGas,omitempty"`
+	MaxFeePerGas         string `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas string `json:"maxPriorityFeePerGas"`
+	// EstimatedFee is the expected cost in wei at the current base fee;
+	// MaxFee is the most the transaction could cost at the fee cap.
+	EstimatedFee string `json:"estimatedFee,omitempty"`
+	MaxFee       string `json:"maxFee,omitempty"`
+	RevertReason string `json:"revertReason,omitempty"`
+}
+
+// unsignedTx stands in for the signer during simulations, so a remote signer
+// is never asked to approve a transaction that will not be sent. Only the
+// call fields of the returned transaction are used.
+func unsignedTx(_ common.Address, tx *types.Transaction) (*types.Transaction, error) {
+	return tx, nil
+}
+
+// simulate builds the transaction fn would send, without broadcasting it, and
+// runs it through eth_call and eth_estimateGas as auth. A revert is
+// reported in the response rather than as an error.
+func (t *txSender) simulate(client *rpcpool.Pool, auth *bind.TransactOpts, fn txFunc) (*SimulationResponse, error) {
+	ctx := context.Background()
+
+	fees, err := t.gas.Fees(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error pricing transaction: %v", err)
+	}
+
+	opts := *auth
+	opts.Context = ctx
+	opts.NoSend = true
+	opts.Signer = unsignedTx
+	opts.GasLimit = simulationGasLimit
+	opts.GasPrice = nil
+	opts.GasFeeCap = fees.GasFeeCap
+	opts.GasTipCap = fees.GasTipCap
+
+	tx, err := fn(&opts)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &SimulationResponse{
+		DryRun:               true,
+		From:                 auth.From.Hex(),
+		MaxFeePerGas:         fees.GasFeeCap.String(),
+		MaxPriorityFeePerGas: fees.GasTipCap.String(),
+	}
+	if tx.To() != nil {
+		resp.To = tx.To().Hex()
+	}
+
+	msg := ethereum.CallMsg{
+		From:  auth.From,
+		To:    tx.To(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}
+	if _, err := client.CallContract(ctx, msg, nil); err != nil {
+		if !utils.IsRevert(err) {
+			return nil, err
+		}
+		resp.RevertReason = utils.RevertReason(err)
+		return resp, nil
+	}
+
+	gasLimit, err := client.EstimateGas(ctx, msg)
+	if err != nil {
+		if !utils.IsRevert(err) {
+			return nil, err
+		}
+		resp.RevertReason = utils.RevertReason(err)
+		return resp, nil
+	}
+
+	gasUsed := new(big.Int).SetUint64(gasLimit)
+	price := new(big.Int).Add(fees.BaseFee, fees.GasTipCap)
+	if price.Cmp(fees.GasFeeCap) > 0 {
+		price = fees.GasFeeCap
+	}
+
+	resp.Success = true
+	resp.EstimatedGas = gasLimit
+	resp.EstimatedFee = new(big.Int).Mul(gasUsed, price).String()
+	resp.MaxFee = new(big.Int).Mul(gasUsed, fees.GasFeeCap).String()
+	return resp, nil
+}
@@ -5,30 +5,54 @@ import (
 	"fmt"
 	"log"
 	"math/big"
+	"strings"
 	erc20 "tokenhub-api/contracts/ERC20"
+	"tokenhub-api/internal/gas"
+	"tokenhub-api/internal/indexer"
+	"tokenhub-api/internal/nonce"
+	"tokenhub-api/internal/registry"
+	"tokenhub-api/internal/rpcpool"
+	"tokenhub-api/internal/signer"
+	"tokenhub-api/internal/txtracker"
+	"tokenhub-api/internal/utils"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
-
-	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 type TokenService interface {
 	GetERC20Details(walletAddr string, contractAddr string) (*ERC20BalanceResponse, error)
-	DeployERC20(name, symbol string, initialSupply *big.Int) (*ERC20DeployResponse, error)
-	MintERC20(contractAddr common.Address, to string, amount *big.Int) (string, error)
-	BurnERC20(contractAddr common.Address, amount *big.Int) (string, error)
+	DeployERC20(signerName, name, symbol string, initialSupply *big.Int, alias string, labels []string) (*ERC20DeployResponse, error)
+	MintERC20(signerName string, contractAddr common.Address, to string, amount *big.Int) (string, error)
+	BurnERC20(signerName string, contractAddr common.Address, amount *big.Int) (string, error)
+	TransferERC20(contractAddr common.Address, to string, amount *big.Int) (string, error)
+	TransferFromERC20(contractAddr common.Address, from, to string, amount *big.Int) (string, error)
+	ApproveERC20(contractAddr common.Address, spender string, amount *big.Int) (*ERC20ApprovalResponse, error)
+	RevokeERC20Approval(contractAddr common.Address, spender string) (*ERC20ApprovalResponse, error)
+	GetERC20Allowance(ownerAddr, spenderAddr, contractAddr string) (*ERC20AllowanceResponse, error)
+	PauseERC20(contractAddr common.Address) (string, error)
+	UnpauseERC20(contractAddr common.Address) (string, error)
+	RescueERC20Funds(contractAddr common.Address, token string, amount *big.Int) (*ERC20RescueResponse, error)
+	SimulateDeployERC20(signerName, name, symbol string, initialSupply *big.Int) (*SimulationResponse, error)
+	SimulateMintERC20(signerName string, contractAddr common.Address, to string, amount *big.Int) (*SimulationResponse, error)
+	SimulateBurnERC20(signerName string, contractAddr common.Address, amount *big.Int) (*SimulationResponse, error)
 }
 
 type tokenService struct {
-	client       *ethclient.Client
-	erc20        *erc20.Contracts
-	erc20Address common.Address
-	auth         *bind.TransactOpts
+	client    *rpcpool.Pool
+	contracts *registry.Registry
+	indexer   *indexer.Indexer
+	txSender
 }
 
-func NewTokenService(client *ethclient.Client, auth *bind.TransactOpts) TokenService {
-	return &tokenService{client: client, auth: auth}
+func NewTokenService(client *rpcpool.Pool, signers *signer.Registry, nonces *nonce.Manager, gasStrategy *gas.Strategy, tracker *txtracker.Tracker, contracts *registry.Registry, idx *indexer.Indexer) TokenService {
+	return &tokenService{
+		client:    client,
+		contracts: contracts,
+		indexer:   idx,
+		txSender:  txSender{signers: signers, nonces: nonces, gas: gasStrategy, tracker: tracker},
+	}
 }
 
 type ERC20BalanceResponse struct {
@@ -36,6 +60,8 @@ type ERC20BalanceResponse struct {
 	TokenSymbol string `json:"tokenSymbol"`
 	Address     string `json:"address"`
 	Balance     string `json:"balance"`
+	// Paused reports whether the contract is paused; mints and transfers revert while it is.
+	Paused bool `json:"paused"`
 }
 
 func (s *tokenService) GetERC20Details(walletAddr string, contractAddr string) (*ERC20BalanceResponse, error) {
@@ -68,6 +94,11 @@ func (s *tokenService) GetERC20Details(walletAddr string, contractAddr string) (
 		return nil, err
 	}
 
+	paused, err := erc20.Paused(&bind.CallOpts{})
+	if err != nil {
+		return nil, err
+	}
+
 	convertedBalance := new(big.Float).Quo(
 		new(big.Float).SetInt(balance),
 		new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)),
@@ -80,6 +111,7 @@ func (s *tokenService) GetERC20Details(walletAddr string, contractAddr string) (
 		TokenSymbol: symbol,
 		Address:     contract.Hex(),
 		Balance:     convertedBalance.String(),
+		Paused:      paused,
 	}, nil
 }
 
@@ -88,18 +120,44 @@ type ERC20DeployResponse struct {
 	TokenSymbol string `json:"tokenSymbol"`
 	Address     string `json:"address"`
 	TotalSupply string `json:"totalSupply"`
+	Alias       string `json:"alias,omitempty"`
+	// Warning explains why the contract is missing from the registry or was
+	// registered without the requested alias.
+	Warning string `json:"warning,omitempty"`
 }
 
-func (s *tokenService) DeployERC20(name, symbol string, initialSupply *big.Int) (*ERC20DeployResponse, error) {
-	decimals := 18
-	scaleFactor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+// erc20DeployDecimals is the decimals() of the TokenHub ERC20 contract.
+const erc20DeployDecimals = 18
+
+func (s *tokenService) DeployERC20(signerName, name, symbol string, initialSupply *big.Int, alias string, labels []string) (*ERC20DeployResponse, error) {
+	auth, err := s.signers.Get(signerName)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.contracts.CheckAlias(alias); err != nil {
+		return nil, err
+	}
+
+	scaleFactor := decimalsFactor(erc20DeployDecimals)
 	scaledSupply := new(big.Int).Mul(initialSupply, scaleFactor)
 
-	address, tx, instance, err := erc20.DeployContracts(s.auth, s.client, name, symbol, scaledSupply)
+	var (
+		address  common.Address
+		instance *erc20.Contracts
+	)
+	tx, err := s.send(auth, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		var (
+			tx  *types.Transaction
+			err error
+		)
+		address, tx, instance, err = erc20.DeployContracts(opts, s.client, name, symbol, scaledSupply)
+		return tx, err
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	s.tracker.Track(tx, auth.From, "deploy", utils.StandardERC20, address)
 	log.Printf("ERC20 deployed at: %s (tx: %s)", address.Hex(), tx.Hash().Hex())
 
 	_, err = bind.WaitDeployed(context.Background(), s.client, tx)
@@ -130,18 +188,136 @@ func (s *tokenService) DeployERC20(name, symbol string, initialSupply *big.Int)
 
 	humanReadableSupplyStr := humanReadableSupply.Text('f', 0)
 
-	s.erc20 = instance
-	s.erc20Address = address
-
-	return &ERC20DeployResponse{
+	resp := &ERC20DeployResponse{
 		TokenName:   tokenName,
 		TokenSymbol: tokenSymbol,
 		Address:     address.Hex(),
 		TotalSupply: humanReadableSupplyStr,
+	}
+
+	resp.Alias, resp.Warning = recordDeployment(s.client, s.contracts, s.indexer, tx, registry.Contract{
+		Address:  address,
+		Standard: utils.StandardERC20,
+		Name:     tokenName,
+		Symbol:   tokenSymbol,
+		Alias:    alias,
+		Labels:   labels,
+		Deployer: auth.From,
+	})
+
+	return resp, nil
+}
+
+func (s *tokenService) MintERC20(signerName string, contractAddr common.Address, to string, amount *big.Int) (string, error) {
+	auth, err := s.signers.Get(signerName)
+	if err != nil {
+		return "", err
+	}
+
+	mint, err := s.mintERC20Tx(contractAddr, to, amount)
+	if err != nil {
+		return "", err
+	}
+	tx, err := s.send(auth, mint)
+	if err != nil {
+		return "", err
+	}
+
+	s.tracker.Track(tx, auth.From, "mint", utils.StandardERC20, contractAddr)
+	log.Println("Minted ERC20 token:", tx.Hash().Hex())
+	return tx.Hash().Hex(), nil
+}
+
+func (s *tokenService) mintERC20Tx(contractAddr common.Address, to string, amount *big.Int) (txFunc, error) {
+	instance, err := erc20.NewContracts(contractAddr, s.client)
+	if err != nil {
+		return nil, err
+	}
+
+	decimals, err := instance.Decimals(&bind.CallOpts{})
+	if err != nil {
+		return nil, err
+	}
+
+	scaledAmount := new(big.Int).Mul(amount, decimalsFactor(decimals))
+	toAddr := common.HexToAddress(to)
+	return func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return instance.Mint(opts, toAddr, scaledAmount)
 	}, nil
 }
 
-func (s *tokenService) MintERC20(contractAddr common.Address, to string, amount *big.Int) (string, error) {
+func (s *tokenService) BurnERC20(signerName string, contractAddr common.Address, amount *big.Int) (string, error) {
+	auth, err := s.signers.Get(signerName)
+	if err != nil {
+		return "", err
+	}
+
+	burn, err := s.burnERC20Tx(contractAddr, amount)
+	if err != nil {
+		return "", err
+	}
+	tx, err := s.send(auth, burn)
+	if err != nil {
+		return "", err
+	}
+	s.tracker.Track(tx, auth.From, "burn", utils.StandardERC20, contractAddr)
+	log.Println("Burned ERC20:", tx.Hash().Hex())
+	return tx.Hash().Hex(), nil
+}
+
+func (s *tokenService) burnERC20Tx(contractAddr common.Address, amount *big.Int) (txFunc, error) {
+	instance, err := erc20.NewContracts(contractAddr, s.client)
+	if err != nil {
+		return nil, err
+	}
+
+	scaledAmount := new(big.Int).Mul(amount, decimalsFactor(erc20DeployDecimals))
+	return func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return instance.Burn(opts, scaledAmount)
+	}, nil
+}
+
+func (s *tokenService) SimulateDeployERC20(signerName, name, symbol string, initialSupply *big.Int) (*SimulationResponse, error) {
+	auth, err := s.signers.Get(signerName)
+	if err != nil {
+		return nil, err
+	}
+
+	scaledSupply := new(big.Int).Mul(initialSupply, decimalsFactor(erc20DeployDecimals))
+	return s.simulate(s.client, auth, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		_, tx, _, err := erc20.DeployContracts(opts, s.client, name, symbol, scaledSupply)
+		return tx, err
+	})
+}
+
+func (s *tokenService) SimulateMintERC20(signerName string, contractAddr common.Address, to string, amount *big.Int) (*SimulationResponse, error) {
+	auth, err := s.signers.Get(signerName)
+	if err != nil {
+		return nil, err
+	}
+
+	mint, err := s.mintERC20Tx(contractAddr, to, amount)
+	if err != nil {
+		return nil, err
+	}
+	return s.simulate(s.client, auth, mint)
+}
+
+func (s *tokenService) SimulateBurnERC20(signerName string, contractAddr common.Address, amount *big.Int) (*SimulationResponse, error) {
+	auth, err := s.signers.Get(signerName)
+	if err != nil {
+		return nil, err
+	}
+
+	burn, err := s.burnERC20Tx(contractAddr, amount)
+	if err != nil {
+		return nil, err
+	}
+	return s.simulate(s.client, auth, burn)
+}
+
+func (s *tokenService) TransferERC20(contractAddr common.Address, to string, amount *big.Int) (string, error) {
+	auth := s.signers.Default()
 	instance, err := erc20.NewContracts(contractAddr, s.client)
 	if err != nil {
 		return "", err
@@ -152,31 +328,254 @@ func (s *tokenService) MintERC20(contractAddr common.Address, to string, amount
 		return "", err
 	}
 
-	multiplier := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
-	scaledAmount := new(big.Int).Mul(amount, multiplier)
+	scaledAmount := new(big.Int).Mul(amount, decimalsFactor(decimals))
 
 	toAddr := common.HexToAddress(to)
-	tx, err := instance.Mint(s.auth, toAddr, scaledAmount)
+	tx, err := s.send(auth, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return instance.Transfer(opts, toAddr, scaledAmount)
+	})
 	if err != nil {
 		return "", err
 	}
 
-	log.Println("Minted ERC20 token:", tx.Hash().Hex())
+	s.tracker.Track(tx, auth.From, "transfer", utils.StandardERC20, contractAddr)
+	log.Println("Transferred ERC20 token:", tx.Hash().Hex())
 	return tx.Hash().Hex(), nil
 }
 
-func (s *tokenService) BurnERC20(contractAddr common.Address, amount *big.Int) (string, error) {
+func (s *tokenService) TransferFromERC20(contractAddr common.Address, from, to string, amount *big.Int) (string, error) {
+	auth := s.signers.Default()
 	instance, err := erc20.NewContracts(contractAddr, s.client)
 	if err != nil {
 		return "", err
 	}
 
-	scaledAmount := new(big.Int).Mul(amount, new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil))
+	decimals, err := instance.Decimals(&bind.CallOpts{})
+	if err != nil {
+		return "", err
+	}
 
-	tx, err := instance.Burn(s.auth, scaledAmount)
+	scaledAmount := new(big.Int).Mul(amount, decimalsFactor(decimals))
+
+	fromAddr := common.HexToAddress(from)
+	toAddr := common.HexToAddress(to)
+	tx, err := s.send(auth, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return instance.TransferFrom(opts, fromAddr, toAddr, scaledAmount)
+	})
 	if err != nil {
 		return "", err
 	}
-	log.Println("Burned ERC20:", tx.Hash().Hex())
+
+	s.tracker.Track(tx, auth.From, "transferFrom", utils.StandardERC20, contractAddr)
+	log.Println("Transferred ERC20 token from", fromAddr.Hex(), ":", tx.Hash().Hex())
+	return tx.Hash().Hex(), nil
+}
+
+type ERC20ApprovalResponse struct {
+	TransactionHash string `json:"transactionHash"`
+	Address         string `json:"address"`
+	Spender         string `json:"spender"`
+	Amount          string `json:"amount"`
+}
+
+func (s *tokenService) ApproveERC20(contractAddr common.Address, spender string, amount *big.Int) (*ERC20ApprovalResponse, error) {
+	auth := s.signers.Default()
+	instance, err := erc20.NewContracts(contractAddr, s.client)
+	if err != nil {
+		return nil, err
+	}
+
+	decimals, err := instance.Decimals(&bind.CallOpts{})
+	if err != nil {
+		return nil, err
+	}
+
+	scaledAmount := new(big.Int).Mul(amount, decimalsFactor(decimals))
+
+	spenderAddr := common.HexToAddress(spender)
+	tx, err := s.send(auth, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return instance.Approve(opts, spenderAddr, scaledAmount)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.tracker.Track(tx, auth.From, "approve", utils.StandardERC20, contractAddr)
+	log.Println("Approved ERC20 spender", spenderAddr.Hex(), ":", tx.Hash().Hex())
+	return &ERC20ApprovalResponse{
+		TransactionHash: tx.Hash().Hex(),
+		Address:         contractAddr.Hex(),
+		Spender:         spenderAddr.Hex(),
+		Amount:          formatTokenAmount(scaledAmount, decimals),
+	}, nil
+}
+
+// RevokeERC20Approval sets the spender's allowance over the server wallet back to zero.
+func (s *tokenService) RevokeERC20Approval(contractAddr common.Address, spender string) (*ERC20ApprovalResponse, error) {
+	return s.ApproveERC20(contractAddr, spender, big.NewInt(0))
+}
+
+type ERC20AllowanceResponse struct {
+	TokenName   string `json:"tokenName"`
+	TokenSymbol string `json:"tokenSymbol"`
+	Address     string `json:"address"`
+	Owner       string `json:"owner"`
+	Spender     string `json:"spender"`
+	Allowance   string `json:"allowance"`
+}
+
+func (s *tokenService) GetERC20Allowance(ownerAddr, spenderAddr, contractAddr string) (*ERC20AllowanceResponse, error) {
+	owner := common.HexToAddress(ownerAddr)
+	spender := common.HexToAddress(spenderAddr)
+	contract := common.HexToAddress(contractAddr)
+
+	instance, err := erc20.NewContracts(contract, s.client)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := instance.Name(&bind.CallOpts{})
+	if err != nil {
+		return nil, err
+	}
+	symbol, err := instance.Symbol(&bind.CallOpts{})
+	if err != nil {
+		return nil, err
+	}
+	decimals, err := instance.Decimals(&bind.CallOpts{})
+	if err != nil {
+		return nil, err
+	}
+
+	allowance, err := instance.Allowance(&bind.CallOpts{}, owner, spender)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ERC20AllowanceResponse{
+		TokenName:   name,
+		TokenSymbol: symbol,
+		Address:     contract.Hex(),
+		Owner:       owner.Hex(),
+		Spender:     spender.Hex(),
+		Allowance:   formatTokenAmount(allowance, decimals),
+	}, nil
+}
+
+// decimalsFactor returns 10^decimals, the number of base units in one whole token.
+func decimalsFactor(decimals uint8) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+}
+
+// formatTokenAmount renders a base-unit amount as an exact decimal string,
+// e.g. 1500000000000000000 with 18 decimals becomes "1.5".
+func formatTokenAmount(amount *big.Int, decimals uint8) string {
+	whole, frac := new(big.Int).QuoRem(amount, decimalsFactor(decimals), new(big.Int))
+	if frac.Sign() == 0 {
+		return whole.String()
+	}
+
+	fracStr := fmt.Sprintf("%0*s", int(decimals), frac.String())
+	return whole.String() + "." + strings.TrimRight(fracStr, "0")
+}
+
+func (s *tokenService) PauseERC20(contractAddr common.Address) (string, error) {
+	auth := s.signers.Default()
+	instance, err := erc20.NewContracts(contractAddr, s.client)
+	if err != nil {
+		return "", err
+	}
+
+	tx, err := s.send(auth, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return instance.Pause(opts)
+	})
+	if err != nil {
+		return "", err
+	}
+	s.tracker.Track(tx, auth.From, "pause", utils.StandardERC20, contractAddr)
+	log.Println("Paused ERC20:", tx.Hash().Hex())
 	return tx.Hash().Hex(), nil
 }
+
+func (s *tokenService) UnpauseERC20(contractAddr common.Address) (string, error) {
+	auth := s.signers.Default()
+	instance, err := erc20.NewContracts(contractAddr, s.client)
+	if err != nil {
+		return "", err
+	}
+
+	tx, err := s.send(auth, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return instance.Unpause(opts)
+	})
+	if err != nil {
+		return "", err
+	}
+	s.tracker.Track(tx, auth.From, "unpause", utils.StandardERC20, contractAddr)
+	log.Println("Unpaused ERC20:", tx.Hash().Hex())
+	return tx.Hash().Hex(), nil
+}
+
+type ERC20RescueResponse struct {
+	TransactionHash string `json:"transactionHash"`
+	Address         string `json:"address"`
+	Token           string `json:"token"`
+	TokenSymbol     string `json:"tokenSymbol"`
+	ContractBalance string `json:"contractBalance"`
+	Amount          string `json:"amount"`
+}
+
+// RescueERC20Funds recovers tokens that were sent to a TokenHub ERC20 contract by
+// mistake. The amount is scaled by the rescued token's decimals and checked
+// against the contract's balance of that token before the tx is submitted.
+func (s *tokenService) RescueERC20Funds(contractAddr common.Address, token string, amount *big.Int) (*ERC20RescueResponse, error) {
+	auth := s.signers.Default()
+	instance, err := erc20.NewContracts(contractAddr, s.client)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenAddr := common.HexToAddress(token)
+	stuck, err := erc20.NewContracts(tokenAddr, s.client)
+	if err != nil {
+		return nil, err
+	}
+
+	symbol, err := stuck.Symbol(&bind.CallOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching symbol: %v", err)
+	}
+	decimals, err := stuck.Decimals(&bind.CallOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching decimals: %v", err)
+	}
+	balance, err := stuck.BalanceOf(&bind.CallOpts{}, contractAddr)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching contract balance: %v", err)
+	}
+
+	log.Printf("Contract %s holds %s %s", contractAddr.Hex(), formatTokenAmount(balance, decimals), symbol)
+
+	scaledAmount := new(big.Int).Mul(amount, decimalsFactor(decimals))
+	if scaledAmount.Cmp(balance) > 0 {
+		return nil, fmt.Errorf("rescue amount %s exceeds contract balance %s %s",
+			formatTokenAmount(scaledAmount, decimals), formatTokenAmount(balance, decimals), symbol)
+	}
+
+	tx, err := s.send(auth, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return instance.RescueFunds(opts, tokenAddr, scaledAmount)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.tracker.Track(tx, auth.From, "rescueFunds", utils.StandardERC20, contractAddr)
+	log.Println("Rescued ERC20 funds:", tx.Hash().Hex())
+
+	return &ERC20RescueResponse{
+		TransactionHash: tx.Hash().Hex(),
+		Address:         contractAddr.Hex(),
+		Token:           tokenAddr.Hex(),
+		TokenSymbol:     symbol,
+		ContractBalance: formatTokenAmount(balance, decimals),
+		Amount:          formatTokenAmount(scaledAmount, decimals),
+	}, nil
+}
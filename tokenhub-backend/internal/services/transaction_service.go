@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"tokenhub-api/internal/gas"
+	"tokenhub-api/internal/nonce"
+	"tokenhub-api/internal/rpcpool"
+	"tokenhub-api/internal/signer"
+	"tokenhub-api/internal/txtracker"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var ErrNotOwnTransaction = errors.New("transaction was not sent by a registered signer")
+
+// TransactionService replaces stuck transactions submitted by the other services.
+type TransactionService interface {
+	SpeedUpTransaction(hash common.Hash) (*ReplacementResponse, error)
+	CancelTransaction(hash common.Hash) (*ReplacementResponse, error)
+}
+
+type transactionService struct {
+	client *rpcpool.Pool
+	txSender
+}
+
+func NewTransactionService(client *rpcpool.Pool, signers *signer.Registry, nonces *nonce.Manager, gasStrategy *gas.Strategy, tracker *txtracker.Tracker) TransactionService {
+	return &transactionService{
+		client:   client,
+		txSender: txSender{signers: signers, nonces: nonces, gas: gasStrategy, tracker: tracker},
+	}
+}
+
+type ReplacementResponse struct {
+	TransactionHash      string `json:"transactionHash"`
+	OriginalHash         string `json:"originalHash"`
+	Nonce                uint64 `json:"nonce"`
+	MaxFeePerGas         string `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas string `json:"maxPriorityFeePerGas"`
+	Status               string `json:"status"`
+}
+
+// SpeedUpTransaction re-sends the original call at the same nonce with bumped fees.
+func (s *transactionService) SpeedUpTransaction(hash common.Hash) (*ReplacementResponse, error) {
+	return s.replace(hash, false)
+}
+
+// CancelTransaction sends a zero-value self-transfer at the original nonce.
+func (s *transactionService) CancelTransaction(hash common.Hash) (*ReplacementResponse, error) {
+	return s.replace(hash, true)
+}
+
+// replace signs and broadcasts a dynamic fee transaction at the nonce of the
+// pending transaction hash, either repeating its call or, when cancel is set,
+// as a zero-value self-transfer.
+func (s *transactionService) replace(hash common.Hash, cancel bool) (*ReplacementResponse, error) {
+	ctx := context.Background()
+
+	original, record, err := s.tracker.Pending(hash)
+	if err != nil {
+		return nil, err
+	}
+	auth, ok := s.signers.ByAddress(common.HexToAddress(record.From))
+	if !ok {
+		return nil, ErrNotOwnTransaction
+	}
+
+	fees, err := s.gas.ReplacementFees(ctx, original.GasTipCap(), original.GasFeeCap())
+	if err != nil {
+		return nil, fmt.Errorf("error pricing replacement: %v", err)
+	}
+
+	action := "speedup"
+	to, value, data, gasLimit := original.To(), original.Value(), original.Data(), original.Gas()
+	if cancel {
+		action = "cancel"
+		self := auth.From
+		to, value, data, gasLimit = &self, common.Big0, nil, params.TxGas
+	}
+
+	tx, err := s.nonces.Replace(ctx, auth, original.Nonce(), func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		unsigned := types.NewTx(&types.DynamicFeeTx{
+			ChainID:   original.ChainId(),
+			Nonce:     opts.Nonce.Uint64(),
+			GasTipCap: fees.GasTipCap,
+			GasFeeCap: fees.GasFeeCap,
+			Gas:       gasLimit,
+			To:        to,
+			Value:     value,
+			Data:      data,
+		})
+		signed, err := opts.Signer(opts.From, unsigned)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.client.SendTransaction(opts.Context, signed); err != nil {
+			return nil, err
+		}
+		return signed, nil
+	})
+	if errors.Is(err, nonce.ErrNotInFlight) {
+		return nil, txtracker.ErrNotPending
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error sending %s transaction: %v", action, err)
+	}
+
+	s.tracker.Replace(hash, tx, auth.From, action)
+	log.Printf("Replaced tx %s with %s %s at nonce %d", hash.Hex(), action, tx.Hash().Hex(), tx.Nonce())
+
+	return &ReplacementResponse{
+		TransactionHash:      tx.Hash().Hex(),
+		OriginalHash:         hash.Hex(),
+		Nonce:                tx.Nonce(),
+		MaxFeePerGas:         fees.GasFeeCap.String(),
+		MaxPriorityFeePerGas: fees.GasTipCap.String(),
+		Status:               "pending",
+	}, nil
+}
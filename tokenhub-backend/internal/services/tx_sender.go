@@ -0,0 +1,39 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"tokenhub-api/internal/gas"
+	"tokenhub-api/internal/nonce"
+	"tokenhub-api/internal/signer"
+	"tokenhub-api/internal/txtracker"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// txSender is embedded by the services that submit transactions. Every write
+// goes through send so it is priced by the gas strategy at send time and gets
+// a managed nonce for the signer it is sent from.
+type txSender struct {
+	signers *signer.Registry
+	nonces  *nonce.Manager
+	gas     *gas.Strategy
+	tracker *txtracker.Tracker
+}
+
+func (t *txSender) send(auth *bind.TransactOpts, fn txFunc) (*types.Transaction, error) {
+	ctx := context.Background()
+
+	fees, err := t.gas.Fees(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error pricing transaction: %v", err)
+	}
+
+	opts := *auth
+	opts.GasPrice = nil
+	opts.GasFeeCap = fees.GasFeeCap
+	opts.GasTipCap = fees.GasTipCap
+
+	return t.nonces.Transact(ctx, &opts, fn)
+}
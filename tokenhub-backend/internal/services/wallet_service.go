@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"tokenhub-api/internal/hdwallet"
+	"tokenhub-api/internal/rpcpool"
+)
+
+var ErrWalletNotFound = errors.New("wallet not found")
+
+// WalletService hands out per-customer deposit addresses derived from the HD wallet.
+type WalletService interface {
+	CreateWallet(label string) (*hdwallet.Account, error)
+	GetWallet(id uint32, contracts WalletContracts) (*WalletResponse, error)
+}
+
+type walletService struct {
+	client   *rpcpool.Pool
+	wallet   *hdwallet.Wallet
+	store    *hdwallet.Store
+	tokenSvc TokenService
+	nftSvc   NFTService
+}
+
+func NewWalletService(client *rpcpool.Pool, wallet *hdwallet.Wallet, store *hdwallet.Store, tokenSvc TokenService, nftSvc NFTService) WalletService {
+	return &walletService{
+		client:   client,
+		wallet:   wallet,
+		store:    store,
+		tokenSvc: tokenSvc,
+		nftSvc:   nftSvc,
+	}
+}
+
+// WalletContracts lists the token contracts to report balances for.
+type WalletContracts struct {
+	ERC20   []string
+	ERC721  []string
+	ERC1155 []string
+}
+
+type WalletResponse struct {
+	hdwallet.Account
+	EthBalance string                 `json:"ethBalance"`
+	ERC20      []ERC20BalanceResponse `json:"erc20,omitempty"`
+	ERC721     []NFTBalanceResponse   `json:"erc721,omitempty"`
+	ERC1155    []NFTBalanceResponse   `json:"erc1155,omitempty"`
+}
+
+func (s *walletService) CreateWallet(label string) (*hdwallet.Account, error) {
+	account, err := s.store.Allocate(s.wallet, label)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Allocated deposit address %s at %s", account.Address.Hex(), account.Path)
+	return account, nil
+}
+
+func (s *walletService) GetWallet(id uint32, contracts WalletContracts) (*WalletResponse, error) {
+	account, ok, err := s.store.Account(id)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrWalletNotFound
+	}
+
+	// Guard against the service being restarted with a different mnemonic.
+	derived, err := s.wallet.Address(id)
+	if err != nil {
+		return nil, err
+	}
+	if derived != account.Address {
+		return nil, fmt.Errorf("wallet %d was allocated as %s but the loaded mnemonic derives %s", id, account.Address.Hex(), derived.Hex())
+	}
+
+	balance, err := s.client.BalanceAt(context.Background(), account.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &WalletResponse{Account: *account, EthBalance: formatTokenAmount(balance, weiDecimals)}
+	wallet := account.Address.Hex()
+
+	for _, contract := range contracts.ERC20 {
+		details, err := s.tokenSvc.GetERC20Details(wallet, contract)
+		if err != nil {
+			return nil, fmt.Errorf("erc20 %s: %w", contract, err)
+		}
+		resp.ERC20 = append(resp.ERC20, *details)
+	}
+	for _, contract := range contracts.ERC721 {
+		details, err := s.nftSvc.GetERC721Details(wallet, contract)
+		if err != nil {
+			return nil, fmt.Errorf("erc721 %s: %w", contract, err)
+		}
+		resp.ERC721 = append(resp.ERC721, *details)
+	}
+	for _, contract := range contracts.ERC1155 {
+		details, err := s.nftSvc.GetERC1155Details(wallet, contract, nil)
+		if err != nil {
+			return nil, fmt.Errorf("erc1155 %s: %w", contract, err)
+		}
+		resp.ERC1155 = append(resp.ERC1155, *details)
+	}
+	return resp, nil
+}
@@ -0,0 +1,121 @@
+package signer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DefaultName is the name the WALLET_* signer is registered under.
+const DefaultName = "default"
+
+// LoadFromEnv builds a registry from the environment. Each signer is read from
+// variables sharing a prefix: WALLET for the default signer, and
+// SIGNER_<NAME> for every name in SIGNERS, upper-cased with '-' as '_'.
+//
+//	<PREFIX>_REMOTE_URL     external signer speaking account_signTransaction, with
+//	<PREFIX>_ADDRESS        the account it signs for
+//	<PREFIX>_KEYSTORE       keystore JSON file
+//	<PREFIX>_PASSWORD_FILE  file holding its passphrase, or
+//	<PREFIX>_PASSWORD       the passphrase itself
+//	<PREFIX>_PRIVATE_KEY    plaintext hex key, deprecated
+//
+// DEFAULT_SIGNER optionally overrides the default. With
+// REQUIRE_REMOTE_SIGNERS=true any signer holding its key in this process is
+// rejected.
+//
+// A non-empty envPrefix, e.g. HOLESKY, is prepended to WALLET, SIGNERS,
+// SIGNER_<NAME> and DEFAULT_SIGNER so each network can use its own accounts.
+func LoadFromEnv(chainID *big.Int, envPrefix string) (*Registry, error) {
+	if envPrefix != "" {
+		envPrefix += "_"
+	}
+	registry := NewRegistry(chainID)
+	requireRemote, _ := strconv.ParseBool(os.Getenv("REQUIRE_REMOTE_SIGNERS"))
+
+	var configured [][2]string // name, variable prefix
+	if envSet(envPrefix + "WALLET") {
+		configured = append(configured, [2]string{DefaultName, envPrefix + "WALLET"})
+	}
+	for _, name := range strings.Split(os.Getenv(envPrefix+"SIGNERS"), ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		configured = append(configured, [2]string{name, envPrefix + "SIGNER_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))})
+	}
+
+	for _, c := range configured {
+		name, prefix := c[0], c[1]
+		s, err := signerFromEnv(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("signer %q: %v", name, err)
+		}
+		if s == nil {
+			return nil, fmt.Errorf("signer %q: set %s_REMOTE_URL or %s_KEYSTORE", name, prefix, prefix)
+		}
+		if _, remote := s.(*RemoteSigner); requireRemote && !remote {
+			return nil, fmt.Errorf("signer %q holds its key in process, but REQUIRE_REMOTE_SIGNERS is set", name)
+		}
+		if err := registry.Add(name, s); err != nil {
+			return nil, err
+		}
+	}
+
+	if registry.Len() == 0 {
+		return nil, fmt.Errorf("no signers configured; set %[1]sWALLET_REMOTE_URL, %[1]sWALLET_KEYSTORE or %[1]sSIGNERS", envPrefix)
+	}
+	if name := os.Getenv(envPrefix + "DEFAULT_SIGNER"); name != "" {
+		if err := registry.SetDefault(name); err != nil {
+			return nil, err
+		}
+	}
+	return registry, nil
+}
+
+// envSet reports whether any signer variable is set under prefix.
+func envSet(prefix string) bool {
+	for _, suffix := range []string{"_REMOTE_URL", "_KEYSTORE", "_PRIVATE_KEY"} {
+		if os.Getenv(prefix+suffix) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// signerFromEnv builds the signer configured under prefix, preferring a
+// remote signer, then a keystore, then a plaintext key. It returns nil when
+// none is set.
+func signerFromEnv(prefix string) (Signer, error) {
+	if url := os.Getenv(prefix + "_REMOTE_URL"); url != "" {
+		address := os.Getenv(prefix + "_ADDRESS")
+		if !common.IsHexAddress(address) {
+			return nil, fmt.Errorf("%s_ADDRESS must be the account the remote signer signs for", prefix)
+		}
+		return NewRemoteSigner(context.Background(), url, common.HexToAddress(address))
+	}
+
+	if path := os.Getenv(prefix + "_KEYSTORE"); path != "" {
+		passphrase, err := ReadPassphrase(os.Getenv(prefix+"_PASSWORD_FILE"), prefix+"_PASSWORD")
+		if err != nil {
+			return nil, err
+		}
+		return NewKeystoreSigner(path, passphrase)
+	}
+
+	if key := os.Getenv(prefix + "_PRIVATE_KEY"); key != "" {
+		log.Printf("%s_PRIVATE_KEY holds a plaintext key; move it to a keystore with `tokenhub-api keystore import`", prefix)
+		s, err := NewHexKeySigner(key)
+		if err != nil {
+			return nil, fmt.Errorf("%s_PRIVATE_KEY: %v", prefix, err)
+		}
+		return s, nil
+	}
+	return nil, nil
+}
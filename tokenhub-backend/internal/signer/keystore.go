@@ -0,0 +1,52 @@
+package signer
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+)
+
+// KeystoreSigner signs with the key of a Web3 Secret Storage (keystore JSON)
+// file, decrypted once at startup.
+type KeystoreSigner struct {
+	*KeySigner
+	path string
+}
+
+func NewKeystoreSigner(path, passphrase string) (*KeystoreSigner, error) {
+	keyJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("decrypting %s: %v", path, err)
+	}
+	return &KeystoreSigner{KeySigner: NewKeySigner(key.PrivateKey), path: path}, nil
+}
+
+// Path is the keystore file the key was loaded from.
+func (s *KeystoreSigner) Path() string {
+	return s.path
+}
+
+// ReadPassphrase returns the passphrase stored in file, with the trailing
+// newline removed, or the value of env when file is empty.
+func ReadPassphrase(file, env string) (string, error) {
+	if file != "" {
+		raw, err := os.ReadFile(file)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimRight(string(raw), "\r\n"), nil
+	}
+	if env != "" {
+		if passphrase, ok := os.LookupEnv(env); ok {
+			return passphrase, nil
+		}
+	}
+	return "", fmt.Errorf("no passphrase: set a password file or %s", env)
+}
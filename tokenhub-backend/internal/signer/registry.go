@@ -0,0 +1,145 @@
+package signer
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var ErrUnknownSigner = errors.New("unknown signer")
+
+// Registry holds the named signers the backend sends from. Requests pick a
+// signer by name; an empty name means the default signer.
+type Registry struct {
+	chainID *big.Int
+
+	mu          sync.RWMutex
+	signers     map[string]entry
+	defaultName string
+}
+
+// entry is a registered signer and the transact options built from it.
+type entry struct {
+	signer Signer
+	auth   *bind.TransactOpts
+	kind   string
+}
+
+// NewRegistry returns an empty registry whose signers sign for chainID.
+func NewRegistry(chainID *big.Int) *Registry {
+	return &Registry{chainID: chainID, signers: make(map[string]entry)}
+}
+
+// Add registers s under name. The first signer added becomes the default.
+func (r *Registry) Add(name string, s Signer) error {
+	if name == "" {
+		return errors.New("signer name is required")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.signers[name]; ok {
+		return fmt.Errorf("signer %q is already registered", name)
+	}
+	r.signers[name] = entry{signer: s, auth: TransactOpts(s, r.chainID), kind: kindOf(s)}
+	if r.defaultName == "" {
+		r.defaultName = name
+	}
+	return nil
+}
+
+func (r *Registry) SetDefault(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.signers[name]; !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownSigner, name)
+	}
+	r.defaultName = name
+	return nil
+}
+
+// Get returns the signer called name, or the default signer when name is empty.
+func (r *Registry) Get(name string) (*bind.TransactOpts, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if name == "" {
+		name = r.defaultName
+	}
+	e, ok := r.signers[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownSigner, name)
+	}
+	return e.auth, nil
+}
+
+// Default returns the default signer, or nil when the registry is empty.
+func (r *Registry) Default() *bind.TransactOpts {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.signers[r.defaultName].auth
+}
+
+func (r *Registry) DefaultName() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.defaultName
+}
+
+// ByAddress returns the signer whose key controls addr.
+func (r *Registry) ByAddress(addr common.Address) (*bind.TransactOpts, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, e := range r.signers {
+		if e.auth.From == addr {
+			return e.auth, true
+		}
+	}
+	return nil, false
+}
+
+// Info is the public description of a registered signer.
+type Info struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+	Kind    string `json:"kind"`
+	Default bool   `json:"default"`
+}
+
+// List returns the registered signers sorted by name.
+func (r *Registry) List() []Info {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	list := make([]Info, 0, len(r.signers))
+	for name, e := range r.signers {
+		list = append(list, Info{Name: name, Address: e.auth.From.Hex(), Kind: e.kind, Default: name == r.defaultName})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+func (r *Registry) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.signers)
+}
+
+func kindOf(s Signer) string {
+	switch s.(type) {
+	case *RemoteSigner:
+		return "remote"
+	case *KeystoreSigner:
+		return "keystore"
+	default:
+		return "key"
+	}
+}
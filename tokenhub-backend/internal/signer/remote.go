@@ -0,0 +1,101 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// RemoteSigner asks an external signer, such as Clef, to sign over HTTP
+// JSON-RPC with account_signTransaction. The key never enters this process.
+type RemoteSigner struct {
+	client  *rpc.Client
+	address common.Address
+}
+
+// signTxResult is the account_signTransaction response.
+type signTxResult struct {
+	Raw hexutil.Bytes `json:"raw"`
+}
+
+// NewRemoteSigner connects to the signer at url, which signs for address.
+func NewRemoteSigner(ctx context.Context, url string, address common.Address) (*RemoteSigner, error) {
+	client, err := rpc.DialContext(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	return &RemoteSigner{client: client, address: address}, nil
+}
+
+func (s *RemoteSigner) Address() common.Address {
+	return s.address
+}
+
+func (s *RemoteSigner) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	args := sendTxArgs(s.address, tx, chainID)
+
+	var result signTxResult
+	if err := s.client.CallContext(ctx, &result, "account_signTransaction", args); err != nil {
+		return nil, fmt.Errorf("remote signer: %v", err)
+	}
+
+	signed := new(types.Transaction)
+	if err := signed.UnmarshalBinary(result.Raw); err != nil {
+		return nil, fmt.Errorf("remote signer returned an invalid transaction: %v", err)
+	}
+	if !sameTx(signed, tx) {
+		return nil, fmt.Errorf("remote signer returned a different transaction")
+	}
+	if err := verifySender(signed, chainID, s.address); err != nil {
+		return nil, fmt.Errorf("remote signer: %v", err)
+	}
+	return signed, nil
+}
+
+func (s *RemoteSigner) Close() {
+	s.client.Close()
+}
+
+// sameTx reports whether a and b carry the same payload, ignoring signatures.
+func sameTx(a, b *types.Transaction) bool {
+	if a.Nonce() != b.Nonce() || a.Gas() != b.Gas() || a.Value().Cmp(b.Value()) != 0 ||
+		a.GasFeeCap().Cmp(b.GasFeeCap()) != 0 || a.GasTipCap().Cmp(b.GasTipCap()) != 0 ||
+		!bytes.Equal(a.Data(), b.Data()) {
+		return false
+	}
+	if a.To() == nil || b.To() == nil {
+		return a.To() == nil && b.To() == nil
+	}
+	return *a.To() == *b.To()
+}
+
+// sendTxArgs encodes tx in the account_signTransaction argument format.
+func sendTxArgs(from common.Address, tx *types.Transaction, chainID *big.Int) apitypes.SendTxArgs {
+	data := hexutil.Bytes(tx.Data())
+	args := apitypes.SendTxArgs{
+		From:    common.NewMixedcaseAddress(from),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   hexutil.Big(*tx.Value()),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		Input:   &data,
+		ChainID: (*hexutil.Big)(chainID),
+	}
+	if to := tx.To(); to != nil {
+		mixed := common.NewMixedcaseAddress(*to)
+		args.To = &mixed
+	}
+	if tx.Type() == types.LegacyTxType {
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	} else {
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+	}
+	return args
+}
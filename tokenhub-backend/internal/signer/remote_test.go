@@ -0,0 +1,130 @@
+package signer
+
+import (
+	"context"
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var testChainID = big.NewInt(11155111)
+
+func newKeySigner(t *testing.T) *KeySigner {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewKeySigner(key)
+}
+
+// startStandIn serves s through NewStandInServer and connects a RemoteSigner
+// for address to it.
+func startStandIn(t *testing.T, s Signer, address common.Address) *RemoteSigner {
+	t.Helper()
+	server, err := NewStandInServer(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+	t.Cleanup(server.Stop)
+
+	remote, err := NewRemoteSigner(context.Background(), httpServer.URL, address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(remote.Close)
+	return remote
+}
+
+func testTx() *types.Transaction {
+	to := common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   testChainID,
+		Nonce:     7,
+		GasTipCap: big.NewInt(params.GWei),
+		GasFeeCap: big.NewInt(3 * params.GWei),
+		Gas:       60000,
+		To:        &to,
+		Value:     big.NewInt(1),
+		Data:      []byte{0xa9, 0x05, 0x9c, 0xbb},
+	})
+}
+
+// tamperingSigner signs a different transaction than it was asked to.
+type tamperingSigner struct {
+	*KeySigner
+}
+
+func (s tamperingSigner) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	attacker := common.HexToAddress("0x00000000000000000000000000000000000BAD00")
+	altered := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     tx.Nonce(),
+		GasTipCap: tx.GasTipCap(),
+		GasFeeCap: tx.GasFeeCap(),
+		Gas:       tx.Gas(),
+		To:        &attacker,
+		Value:     tx.Value(),
+		Data:      tx.Data(),
+	})
+	return s.KeySigner.SignTx(ctx, altered, chainID)
+}
+
+// impostorSigner claims one account but signs with the key of another.
+type impostorSigner struct {
+	*KeySigner
+	claimed common.Address
+}
+
+func (s impostorSigner) Address() common.Address {
+	return s.claimed
+}
+
+func TestRemoteSignerSigns(t *testing.T) {
+	local := newKeySigner(t)
+	remote := startStandIn(t, local, local.Address())
+
+	tx := testTx()
+	signed, err := remote.SignTx(context.Background(), tx, testChainID)
+	if err != nil {
+		t.Fatalf("SignTx: %v", err)
+	}
+	if !sameTx(signed, tx) {
+		t.Fatal("signed transaction differs from the request")
+	}
+	from, err := types.Sender(types.LatestSignerForChainID(testChainID), signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from != local.Address() {
+		t.Fatalf("sender = %s, want %s", from.Hex(), local.Address().Hex())
+	}
+}
+
+func TestRemoteSignerRejectsTamperedTransaction(t *testing.T) {
+	local := newKeySigner(t)
+	remote := startStandIn(t, tamperingSigner{local}, local.Address())
+
+	_, err := remote.SignTx(context.Background(), testTx(), testChainID)
+	if err == nil || !strings.Contains(err.Error(), "different transaction") {
+		t.Fatalf("err = %v, want a different transaction error", err)
+	}
+}
+
+func TestRemoteSignerRejectsWrongSender(t *testing.T) {
+	claimed := newKeySigner(t).Address()
+	remote := startStandIn(t, impostorSigner{KeySigner: newKeySigner(t), claimed: claimed}, claimed)
+
+	_, err := remote.SignTx(context.Background(), testTx(), testChainID)
+	if err == nil || !strings.Contains(err.Error(), "wrong sender") {
+		t.Fatalf("err = %v, want a wrong sender error", err)
+	}
+}
@@ -0,0 +1,54 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Signer signs transactions for one account. Services never touch key
+// material; they only see the transact options built by TransactOpts.
+type Signer interface {
+	Address() common.Address
+	SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+}
+
+// signTimeout bounds a single signing request, which may go over the network.
+const signTimeout = 30 * time.Second
+
+// TransactOpts adapts s to the generated bindings. Nonce and fees are left
+// unset; they are filled in per transaction.
+func TransactOpts(s Signer, chainID *big.Int) *bind.TransactOpts {
+	from := s.Address()
+	return &bind.TransactOpts{
+		From: from,
+		Signer: func(addr common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if addr != from {
+				return nil, bind.ErrNotAuthorized
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), signTimeout)
+			defer cancel()
+			return s.SignTx(ctx, tx, chainID)
+		},
+		Value:   big.NewInt(0),
+		Context: context.Background(),
+	}
+}
+
+// verifySender checks that signed was signed by want, so a misbehaving
+// signer cannot slip in a transaction from another account.
+func verifySender(signed *types.Transaction, chainID *big.Int, want common.Address) error {
+	got, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+	if err != nil {
+		return err
+	}
+	if got != want {
+		return errors.New("signed transaction has the wrong sender " + got.Hex())
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package signer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// NewStandInServer serves account_signTransaction for s over JSON-RPC. It is a
+// local stand-in for an external signer, for development and tests only: it
+// signs every request for its account without confirmation.
+func NewStandInServer(s Signer) (*rpc.Server, error) {
+	server := rpc.NewServer()
+	if err := server.RegisterName("account", &standInAPI{signer: s}); err != nil {
+		return nil, err
+	}
+	return server, nil
+}
+
+type standInAPI struct {
+	signer Signer
+}
+
+// SignTransaction implements account_signTransaction. methodSelector is
+// accepted for compatibility and ignored.
+func (api *standInAPI) SignTransaction(ctx context.Context, args apitypes.SendTxArgs, methodSelector *string) (*signTxResult, error) {
+	if args.From.Address() != api.signer.Address() {
+		return nil, fmt.Errorf("unknown account %s", args.From.Address().Hex())
+	}
+	if args.ChainID == nil {
+		return nil, fmt.Errorf("chainId is required")
+	}
+
+	tx, err := args.ToTransaction()
+	if err != nil {
+		return nil, err
+	}
+	signed, err := api.signer.SignTx(ctx, tx, args.ChainID.ToInt())
+	if err != nil {
+		return nil, err
+	}
+	raw, err := signed.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return &signTxResult{Raw: hexutil.Bytes(raw)}, nil
+}
@@ -0,0 +1,306 @@
+package txtracker
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/big"
+	"sync"
+	"time"
+	"tokenhub-api/internal/rpcpool"
+	"tokenhub-api/internal/utils"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusConfirmed Status = "confirmed"
+	StatusFailed    Status = "failed"
+	// StatusDropped means the tx left the mempool without being mined and its
+	// nonce has since been used by another transaction.
+	StatusDropped Status = "dropped"
+	// StatusReplaced means a speed-up or cancel transaction took the nonce.
+	StatusReplaced Status = "replaced"
+)
+
+var (
+	ErrNotFound   = errors.New("transaction not found")
+	ErrNotPending = errors.New("transaction is no longer pending")
+)
+
+// Record is the tracked state of a transaction submitted by TokenHub.
+type Record struct {
+	Hash              string `json:"hash"`
+	Action            string `json:"action,omitempty"`
+	Standard          string `json:"standard,omitempty"`
+	ContractAddress   string `json:"contractAddress,omitempty"`
+	From              string `json:"from"`
+	To                string `json:"to,omitempty"`
+	Nonce             uint64 `json:"nonce"`
+	Status            Status `json:"status"`
+	SubmittedAt       string `json:"submittedAt,omitempty"`
+	BlockNumber       uint64 `json:"blockNumber,omitempty"`
+	GasUsed           uint64 `json:"gasUsed,omitempty"`
+	EffectiveGasPrice string `json:"effectiveGasPrice,omitempty"`
+	RevertReason      string `json:"revertReason,omitempty"`
+	// Replaces and ReplacedBy link a speed-up or cancel to the original tx.
+	Replaces   string `json:"replaces,omitempty"`
+	ReplacedBy string `json:"replacedBy,omitempty"`
+
+	tx        *types.Transaction
+	submitted time.Time
+}
+
+// retention is how long settled transactions are kept in memory.
+const retention = 24 * time.Hour
+
+// Tracker records submitted transactions and polls their receipts until they
+// are mined or dropped.
+type Tracker struct {
+	client       *rpcpool.Pool
+	pollInterval time.Duration
+
+	mu      sync.Mutex
+	records map[common.Hash]*Record
+}
+
+func New(client *rpcpool.Pool, pollInterval time.Duration) *Tracker {
+	return &Tracker{
+		client:       client,
+		pollInterval: pollInterval,
+		records:      make(map[common.Hash]*Record),
+	}
+}
+
+// Track starts following tx. contract is the token contract the tx acts on,
+// or the zero address when there is none yet (deployments).
+func (t *Tracker) Track(tx *types.Transaction, from common.Address, action, standard string, contract common.Address) {
+	rec := &Record{
+		Hash:      tx.Hash().Hex(),
+		Action:    action,
+		Standard:  standard,
+		From:      from.Hex(),
+		Nonce:     tx.Nonce(),
+		Status:    StatusPending,
+		tx:        tx,
+		submitted: time.Now().UTC(),
+	}
+	rec.SubmittedAt = rec.submitted.Format(time.RFC3339)
+	if contract != (common.Address{}) {
+		rec.ContractAddress = contract.Hex()
+	}
+	if tx.To() != nil {
+		rec.To = tx.To().Hex()
+	}
+
+	t.mu.Lock()
+	t.records[tx.Hash()] = rec
+	t.mu.Unlock()
+}
+
+// Pending returns the signed transaction and state of a tracked transaction
+// that is still waiting to be mined.
+func (t *Tracker) Pending(hash common.Hash) (*types.Transaction, Record, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	rec, ok := t.records[hash]
+	if !ok || rec.tx == nil {
+		return nil, Record{}, ErrNotFound
+	}
+	if rec.Status != StatusPending || rec.ReplacedBy != "" {
+		return nil, Record{}, ErrNotPending
+	}
+	return rec.tx, *rec, nil
+}
+
+// Replace tracks tx as a replacement for the pending transaction original,
+// linking the two records.
+func (t *Tracker) Replace(original common.Hash, tx *types.Transaction, from common.Address, action string) {
+	t.mu.Lock()
+	prev, ok := t.records[original]
+	t.mu.Unlock()
+
+	standard, contract := "", common.Address{}
+	if ok {
+		standard = prev.Standard
+		if prev.ContractAddress != "" {
+			contract = common.HexToAddress(prev.ContractAddress)
+		}
+	}
+	t.Track(tx, from, action, standard, contract)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.records[tx.Hash()].Replaces = original.Hex()
+	if ok {
+		prev.ReplacedBy = tx.Hash().Hex()
+	}
+}
+
+// Get returns the current state of a transaction, refreshing it from the
+// chain while it is pending. Transactions TokenHub did not submit (or that
+// were submitted before a restart) are looked up on chain.
+func (t *Tracker) Get(ctx context.Context, hash common.Hash) (*Record, error) {
+	t.mu.Lock()
+	rec, ok := t.records[hash]
+	t.mu.Unlock()
+
+	if !ok {
+		tx, _, err := t.client.TransactionByHash(ctx, hash)
+		if errors.Is(err, ethereum.NotFound) {
+			return nil, ErrNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+		if err != nil {
+			return nil, err
+		}
+		rec = &Record{Hash: hash.Hex(), From: from.Hex(), Nonce: tx.Nonce(), Status: StatusPending, tx: tx}
+		if tx.To() != nil {
+			rec.To = tx.To().Hex()
+		}
+	}
+
+	if err := t.refresh(ctx, rec); err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	result := *rec
+	return &result, nil
+}
+
+// Run polls pending transactions until ctx is cancelled.
+func (t *Tracker) Run(ctx context.Context) {
+	ticker := time.NewTicker(t.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		t.mu.Lock()
+		var pending []*Record
+		for hash, rec := range t.records {
+			switch {
+			case rec.Status == StatusPending:
+				pending = append(pending, rec)
+			case time.Since(rec.submitted) > retention:
+				delete(t.records, hash)
+			}
+		}
+		t.mu.Unlock()
+
+		for _, rec := range pending {
+			if err := t.refresh(ctx, rec); err != nil {
+				log.Printf("Failed to refresh tx %s: %v", rec.Hash, err)
+			}
+		}
+	}
+}
+
+func (t *Tracker) refresh(ctx context.Context, rec *Record) error {
+	t.mu.Lock()
+	status := rec.Status
+	t.mu.Unlock()
+	if status != StatusPending {
+		return nil
+	}
+
+	hash := common.HexToHash(rec.Hash)
+	receipt, err := t.client.TransactionReceipt(ctx, hash)
+	if errors.Is(err, ethereum.NotFound) {
+		return t.checkDropped(ctx, rec)
+	}
+	if err != nil {
+		return err
+	}
+
+	update := Record{
+		BlockNumber: receipt.BlockNumber.Uint64(),
+		GasUsed:     receipt.GasUsed,
+		Status:      StatusConfirmed,
+	}
+	if receipt.EffectiveGasPrice != nil {
+		update.EffectiveGasPrice = receipt.EffectiveGasPrice.String()
+	}
+	if receipt.ContractAddress != (common.Address{}) {
+		update.ContractAddress = receipt.ContractAddress.Hex()
+	}
+	if receipt.Status == types.ReceiptStatusFailed {
+		update.Status = StatusFailed
+		update.RevertReason = t.revertReason(ctx, rec, receipt.BlockNumber)
+		log.Printf("Transaction %s reverted: %s", rec.Hash, update.RevertReason)
+	}
+
+	t.mu.Lock()
+	rec.Status = update.Status
+	rec.BlockNumber = update.BlockNumber
+	rec.GasUsed = update.GasUsed
+	rec.EffectiveGasPrice = update.EffectiveGasPrice
+	rec.RevertReason = update.RevertReason
+	if update.ContractAddress != "" {
+		rec.ContractAddress = update.ContractAddress
+	}
+	t.mu.Unlock()
+	return nil
+}
+
+// checkDropped marks rec dropped once the tx is unknown to the node and the
+// sender's mined nonce has moved past it.
+func (t *Tracker) checkDropped(ctx context.Context, rec *Record) error {
+	_, _, err := t.client.TransactionByHash(ctx, common.HexToHash(rec.Hash))
+	if err == nil || !errors.Is(err, ethereum.NotFound) {
+		return nil
+	}
+
+	nonce, err := t.client.NonceAt(ctx, common.HexToAddress(rec.From), nil)
+	if err != nil {
+		return err
+	}
+	if nonce > rec.Nonce {
+		t.mu.Lock()
+		rec.Status = StatusDropped
+		if rec.ReplacedBy != "" {
+			rec.Status = StatusReplaced
+		}
+		t.mu.Unlock()
+	}
+	return nil
+}
+
+// revertReason replays a failed transaction with eth_call against the state
+// before its block to recover the revert data.
+func (t *Tracker) revertReason(ctx context.Context, rec *Record, blockNumber *big.Int) string {
+	tx := rec.tx
+	if tx == nil {
+		return ""
+	}
+
+	msg := ethereum.CallMsg{
+		From:  common.HexToAddress(rec.From),
+		To:    tx.To(),
+		Gas:   tx.Gas(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}
+	parent := new(big.Int).Sub(blockNumber, big.NewInt(1))
+	_, err := t.client.CallContract(ctx, msg, parent)
+	if err == nil {
+		// The call succeeds in isolation; the revert depended on earlier txs in the block.
+		return "execution reverted"
+	}
+	return utils.RevertReason(err)
+}
@@ -2,44 +2,25 @@ package utils
 
 import (
 	"math/big"
-
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
-	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
+	"tokenhub-api/internal/rpcpool"
 )
 
 type EthConnection struct {
-	Client *ethclient.Client
-	Auth   *bind.TransactOpts
+	Client  *rpcpool.Pool
+	ChainID *big.Int
 }
 
-func NewEthConnection(rpcURL, privateKeyHex string) (*EthConnection, error) {
-	client, err := ethclient.Dial(rpcURL)
-	if err != nil {
-		return nil, err
-	}
-
-	privateKey, err := crypto.HexToECDSA(privateKeyHex)
-	if err != nil {
-		return nil, err
-	}
-
-	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(11155111)) // Sepolia
-	if err != nil {
-		return nil, err
-	}
-
-	auth.Nonce = nil
-
-	auth.Value = big.NewInt(0)
-	auth.GasLimit = 0
-	auth.GasPrice, err = client.SuggestGasPrice(auth.Context)
+// NewEthConnection opens a failover pool over rpcURLs. Every endpoint must
+// serve chainID, so a misconfigured URL cannot sign transactions for the
+// wrong network.
+func NewEthConnection(rpcURLs []string, chainID *big.Int, cfg rpcpool.Config) (*EthConnection, error) {
+	client, err := rpcpool.Dial(rpcURLs, chainID, cfg)
 	if err != nil {
 		return nil, err
 	}
 
 	return &EthConnection{
-		Client: client,
-		Auth:   auth,
+		Client:  client,
+		ChainID: chainID,
 	}, nil
 }
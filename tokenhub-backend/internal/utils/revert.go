@@ -0,0 +1,95 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	erc1155 "tokenhub-api/contracts/ERC1155"
+	erc20 "tokenhub-api/contracts/ERC20"
+	erc721 "tokenhub-api/contracts/ERC721"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// contractMetaData lists the ABIs whose custom errors can be decoded.
+var contractMetaData = []*bind.MetaData{
+	erc20.ContractsMetaData,
+	erc721.ContractsMetaData,
+	erc1155.ContractsMetaData,
+}
+
+// RevertData extracts the revert payload carried by an eth_call or
+// eth_estimateGas error, if the node returned one.
+func RevertData(err error) ([]byte, bool) {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return nil, false
+	}
+	hexData, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return nil, false
+	}
+	data, decodeErr := hexutil.Decode(hexData)
+	if decodeErr != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// DecodeRevert turns revert data into a readable reason: the Error(string)
+// message, the Panic code, or a TokenHub custom error such as
+// OwnableUnauthorizedAccount(0x...).
+func DecodeRevert(data []byte) string {
+	if len(data) == 0 {
+		return "execution reverted"
+	}
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		return reason
+	}
+	if len(data) >= 4 {
+		for _, md := range contractMetaData {
+			parsed, err := md.GetAbi()
+			if err != nil {
+				continue
+			}
+			for _, abiErr := range parsed.Errors {
+				if !bytes.Equal(abiErr.ID[:4], data[:4]) {
+					continue
+				}
+				values, err := abiErr.Unpack(data)
+				if err != nil {
+					return abiErr.Name
+				}
+				args, _ := values.([]interface{})
+				parts := make([]string, len(args))
+				for i, arg := range args {
+					parts[i] = fmt.Sprint(arg)
+				}
+				return abiErr.Name + "(" + strings.Join(parts, ", ") + ")"
+			}
+		}
+	}
+	return "execution reverted: " + hexutil.Encode(data)
+}
+
+// RevertReason returns the decoded revert reason behind err, falling back to
+// the error message when the node sent no revert data.
+func RevertReason(err error) string {
+	if data, ok := RevertData(err); ok {
+		return DecodeRevert(data)
+	}
+	return err.Error()
+}
+
+// IsRevert reports whether err is an execution revert rather than a transport
+// or node failure.
+func IsRevert(err error) bool {
+	if _, ok := RevertData(err); ok {
+		return true
+	}
+	return strings.Contains(strings.ToLower(err.Error()), "execution reverted")
+}
@@ -0,0 +1,17 @@
+package utils
+
+// Token standards supported by TokenHub, matching the route suffixes.
+const (
+	StandardERC20   = "erc20"
+	StandardERC721  = "erc721"
+	StandardERC1155 = "erc1155"
+)
+
+// IsValidStandard reports whether standard is one of the supported token standards.
+func IsValidStandard(standard string) bool {
+	switch standard {
+	case StandardERC20, StandardERC721, StandardERC1155:
+		return true
+	}
+	return false
+}
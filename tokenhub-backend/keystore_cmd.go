@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"tokenhub-api/internal/signer"
+)
+
+const keystoreUsage = `Usage:
+  tokenhub-api keystore new    [-dir DIR] [-password-file FILE]
+  tokenhub-api keystore import [-dir DIR] [-password-file FILE] [KEYFILE]
+
+new creates a fresh key; import encrypts a hex private key read from KEYFILE,
+or from stdin when KEYFILE is omitted. The passphrase comes from
+-password-file or KEYSTORE_PASSWORD. The keystore path is printed so it can be
+set as WALLET_KEYSTORE or SIGNER_<NAME>_KEYSTORE.
+`
+
+// runKeystore implements the keystore subcommand and returns the exit code.
+func runKeystore(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, keystoreUsage)
+		return 2
+	}
+
+	fs := flag.NewFlagSet("keystore "+args[0], flag.ContinueOnError)
+	dir := fs.String("dir", "keystore", "directory to write the keystore file to")
+	passwordFile := fs.String("password-file", "", "file holding the passphrase")
+	fs.Usage = func() { fmt.Fprint(os.Stderr, keystoreUsage) }
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+
+	passphrase, err := signer.ReadPassphrase(*passwordFile, "KEYSTORE_PASSWORD")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if passphrase == "" {
+		fmt.Fprintln(os.Stderr, "refusing to use an empty passphrase")
+		return 1
+	}
+
+	ks := keystore.NewKeyStore(*dir, keystore.StandardScryptN, keystore.StandardScryptP)
+
+	switch args[0] {
+	case "new":
+		account, err := ks.NewAccount(passphrase)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "creating key:", err)
+			return 1
+		}
+		fmt.Printf("Address:  %s\nKeystore: %s\n", account.Address.Hex(), account.URL.Path)
+	case "import":
+		hexKey, err := readFirstLine(fs.Arg(0))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "reading key:", err)
+			return 1
+		}
+		privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(hexKey, "0x"))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "invalid private key:", err)
+			return 1
+		}
+		account, err := ks.ImportECDSA(privateKey, passphrase)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "importing key:", err)
+			return 1
+		}
+		fmt.Printf("Address:  %s\nKeystore: %s\n", account.Address.Hex(), account.URL.Path)
+	default:
+		fmt.Fprint(os.Stderr, keystoreUsage)
+		return 2
+	}
+	return 0
+}
+
+// readFirstLine reads the first line of path, or of stdin when path is empty, so
+// secrets never have to appear on the command line.
+func readFirstLine(path string) (string, error) {
+	var r io.Reader = os.Stdin
+	if path != "" {
+		f, err := os.Open(path)
+		if err != nil {
+			return "", err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	line, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
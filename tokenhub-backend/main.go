@@ -2,19 +2,38 @@ package main
 
 import (
 	"log"
+	"math/big"
 	"net/http"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/rs/cors"
 	"go.uber.org/zap"
 
+	"tokenhub-api/internal/gas"
+	"tokenhub-api/internal/hdwallet"
+	"tokenhub-api/internal/indexer"
+	"tokenhub-api/internal/network"
+	"tokenhub-api/internal/registry"
 	"tokenhub-api/internal/router"
-	"tokenhub-api/internal/services"
-	"tokenhub-api/internal/utils"
+	"tokenhub-api/internal/rpcpool"
+	"tokenhub-api/internal/signer"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "keystore":
+			os.Exit(runKeystore(os.Args[2:]))
+		case "standin-signer":
+			os.Exit(runStandInSigner(os.Args[2:]))
+		case "hdwallet":
+			os.Exit(runHDWallet(os.Args[2:]))
+		}
+	}
+
 	logger, err := zap.NewDevelopment()
 	if err != nil {
 		log.Fatalf("can't initialize zap logger: %v", err)
@@ -24,25 +43,104 @@ func main() {
 		log.Fatal("Error loading .env file")
 	}
 
-	rpcURL := os.Getenv("SEPOLIA_RPC_URL")
-	privateKey := os.Getenv("WALLET_PRIVATE_KEY")
+	networks, err := network.FromEnv()
+	if err != nil {
+		log.Fatalf("Failed to load networks: %v", err)
+	}
+
+	gasProfile := gas.ProfileNormal
+	if profile := os.Getenv("GAS_PROFILE"); profile != "" {
+		gasProfile, err = gas.ParseProfile(profile)
+		if err != nil {
+			log.Fatalf("Invalid GAS_PROFILE: %v", err)
+		}
+	}
+
+	var maxFeeCap *big.Int
+	if maxFee := os.Getenv("GAS_MAX_FEE_GWEI"); maxFee != "" {
+		maxFeeCap, err = gas.GweiToWei(maxFee)
+		if err != nil {
+			log.Fatalf("Invalid GAS_MAX_FEE_GWEI: %v", err)
+		}
+	}
+
+	indexerCfg := indexer.DefaultConfig()
+	if interval, err := time.ParseDuration(os.Getenv("INDEXER_POLL_INTERVAL")); err == nil {
+		indexerCfg.PollInterval = interval
+	}
+	if confirmations, err := strconv.ParseUint(os.Getenv("INDEXER_CONFIRMATIONS"), 10, 64); err == nil {
+		indexerCfg.Confirmations = confirmations
+	}
 
-	conn, err := utils.NewEthConnection(rpcURL, privateKey)
+	rpcCfg := rpcpool.DefaultConfig()
+	if lag, err := strconv.ParseUint(os.Getenv("RPC_MAX_LAG_BLOCKS"), 10, 64); err == nil {
+		rpcCfg.MaxLag = lag
+	}
+	if interval, err := time.ParseDuration(os.Getenv("RPC_HEALTH_INTERVAL")); err == nil && interval > 0 {
+		rpcCfg.CheckInterval = interval
+	}
+
+	shared := sharedConfig{
+		gasProfile: gasProfile,
+		maxFeeCap:  maxFeeCap,
+		indexer:    indexerCfg,
+		rpc:        rpcCfg,
+	}
+
+	contractsDBPath := os.Getenv("CONTRACTS_DB_PATH")
+	if contractsDBPath == "" {
+		contractsDBPath = "contracts.db"
+	}
+	// Deployments of every network share one registry, keyed by chain id.
+	shared.contracts, err = registry.OpenStore(contractsDBPath)
 	if err != nil {
-		log.Fatalf("Failed to connect: %v", err)
+		log.Fatalf("Failed to open contract registry: %v", err)
 	}
+	defer shared.contracts.Close()
+
+	if mnemonicPath := os.Getenv("HD_WALLET_KEYSTORE"); mnemonicPath != "" {
+		passphrase, err := signer.ReadPassphrase(os.Getenv("HD_WALLET_PASSWORD_FILE"), "HD_WALLET_PASSWORD")
+		if err != nil {
+			log.Fatalf("Failed to read HD wallet passphrase: %v", err)
+		}
+		mnemonic, err := hdwallet.LoadMnemonic(mnemonicPath, passphrase)
+		if err != nil {
+			log.Fatalf("Failed to load HD wallet: %v", err)
+		}
+		shared.wallet, err = hdwallet.NewWallet(mnemonic, "")
+		if err != nil {
+			log.Fatalf("Failed to load HD wallet: %v", err)
+		}
 
-	tokenService := services.NewTokenService(
-		conn.Client,
-		conn.Auth,
-	)
+		walletDBPath := os.Getenv("HD_WALLET_DB_PATH")
+		if walletDBPath == "" {
+			walletDBPath = "wallets.db"
+		}
+		// Addresses are the same on every EVM chain, so all networks share
+		// one allocation table.
+		shared.walletStore, err = hdwallet.OpenStore(walletDBPath)
+		if err != nil {
+			log.Fatalf("Failed to open wallet database: %v", err)
+		}
+		defer shared.walletStore.Close()
+	}
 
-	nftService := services.NewNFTService(
-		conn.Client,
-		conn.Auth,
-	)
+	var served []router.Network
+	for _, cfg := range networks.Networks {
+		n, store, err := setupNetwork(cfg, cfg.Name == networks.Default, shared)
+		if err != nil {
+			log.Fatalf("Network %s: %v", cfg.Name, err)
+		}
+		defer store.Close()
+
+		for _, s := range n.Signers.List() {
+			logger.Info("Signer loaded", zap.String("network", cfg.Name), zap.String("name", s.Name), zap.String("address", s.Address), zap.Bool("default", s.Default))
+		}
+		logger.Info("Network ready", zap.String("network", cfg.Name), zap.Uint64("chainId", cfg.ChainID), zap.Bool("default", cfg.Name == networks.Default))
+		served = append(served, n)
+	}
 
-	r := router.NewRouter(tokenService, nftService, logger)
+	r := router.NewRouter(networks, served, logger)
 	handler := cors.Default().Handler(r)
 
 	logger.Info("Server running", zap.String("address", ":8080"))
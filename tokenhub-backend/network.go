@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"tokenhub-api/internal/gas"
+	"tokenhub-api/internal/hdwallet"
+	"tokenhub-api/internal/indexer"
+	"tokenhub-api/internal/network"
+	"tokenhub-api/internal/nonce"
+	"tokenhub-api/internal/registry"
+	"tokenhub-api/internal/router"
+	"tokenhub-api/internal/rpcpool"
+	"tokenhub-api/internal/services"
+	"tokenhub-api/internal/signer"
+	"tokenhub-api/internal/txtracker"
+	"tokenhub-api/internal/utils"
+)
+
+// sharedConfig holds the settings every network is built with.
+type sharedConfig struct {
+	gasProfile  gas.Profile
+	maxFeeCap   *big.Int
+	indexer     indexer.Config
+	rpc         rpcpool.Config
+	contracts   *registry.Store
+	wallet      *hdwallet.Wallet // nil disables deposit wallets
+	walletStore *hdwallet.Store
+}
+
+// setupNetwork connects to cfg, loads its signers and starts the background
+// workers behind its services. The caller closes the returned indexer store.
+func setupNetwork(cfg network.Network, isDefault bool, shared sharedConfig) (router.Network, *indexer.Store, error) {
+	chainID := cfg.ChainIDBig()
+
+	conn, err := utils.NewEthConnection(cfg.RPCURLs, chainID, shared.rpc)
+	if err != nil {
+		return router.Network{}, nil, fmt.Errorf("failed to connect: %v", err)
+	}
+	go conn.Client.Run(context.Background())
+
+	signers, err := signer.LoadFromEnv(chainID, cfg.SignerEnvPrefix)
+	if err != nil {
+		return router.Network{}, nil, fmt.Errorf("failed to load signers: %v", err)
+	}
+
+	tracker := txtracker.New(conn.Client, 5*time.Second)
+	go tracker.Run(context.Background())
+
+	maxFeeCap := shared.maxFeeCap
+	if cfg.MaxFeeGwei != "" {
+		maxFeeCap, err = gas.GweiToWei(cfg.MaxFeeGwei)
+		if err != nil {
+			return router.Network{}, nil, fmt.Errorf("invalid maxFeeGwei: %v", err)
+		}
+	}
+	gasStrategy := gas.NewStrategy(conn.Client, shared.gasProfile, maxFeeCap)
+
+	nonces := nonce.NewManager(conn.Client, signers, gasStrategy)
+	go nonces.Run(context.Background(), 30*time.Second)
+
+	dbPath := cfg.IndexerDBPath
+	if dbPath == "" && isDefault {
+		dbPath = os.Getenv("INDEXER_DB_PATH")
+	}
+	if dbPath == "" {
+		if isDefault {
+			dbPath = "tokenhub.db"
+		} else {
+			dbPath = "tokenhub-" + cfg.Name + ".db"
+		}
+	}
+
+	store, err := indexer.OpenStore(dbPath)
+	if err != nil {
+		return router.Network{}, nil, fmt.Errorf("failed to open indexer database: %v", err)
+	}
+
+	eventIndexer := indexer.New(conn.Client, store, shared.indexer)
+	go eventIndexer.Run(context.Background())
+
+	contracts := registry.New(shared.contracts, cfg.ChainID)
+
+	n := router.Network{
+		Config:       cfg,
+		RPC:          conn.Client,
+		Contracts:    contracts,
+		Token:        services.NewTokenService(conn.Client, signers, nonces, gasStrategy, tracker, contracts, eventIndexer),
+		NFT:          services.NewNFTService(conn.Client, signers, nonces, gasStrategy, tracker, contracts, eventIndexer),
+		Ownership:    services.NewOwnershipService(conn.Client, signers, nonces, gasStrategy, tracker),
+		Indexer:      eventIndexer,
+		History:      services.NewHistoryService(conn.Client, eventIndexer),
+		Transactions: services.NewTransactionService(conn.Client, signers, nonces, gasStrategy, tracker),
+		Estimate:     services.NewEstimateService(conn.Client, signers, gasStrategy),
+		Tracker:      tracker,
+		Gas:          gasStrategy,
+		Signers:      signers,
+	}
+	if shared.wallet != nil {
+		n.Wallets = services.NewWalletService(conn.Client, shared.wallet, shared.walletStore, n.Token, n.NFT)
+	}
+	return n, store, nil
+}
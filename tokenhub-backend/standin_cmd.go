@@ -0,0 +1,60 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+
+	"tokenhub-api/internal/signer"
+)
+
+const standInUsage = `Usage:
+  tokenhub-api standin-signer -keystore FILE [-password-file FILE] [-listen ADDR]
+
+Serves account_signTransaction for one keystore account, so the API can run
+with WALLET_REMOTE_URL in development. It signs every request without
+confirmation: never use it in production. The passphrase comes from
+-password-file or KEYSTORE_PASSWORD.
+`
+
+// runStandInSigner implements the standin-signer subcommand and returns the exit code.
+func runStandInSigner(args []string) int {
+	fs := flag.NewFlagSet("standin-signer", flag.ContinueOnError)
+	keystorePath := fs.String("keystore", "", "keystore JSON file to sign with")
+	passwordFile := fs.String("password-file", "", "file holding the passphrase")
+	listen := fs.String("listen", "127.0.0.1:8550", "address to serve JSON-RPC on")
+	fs.Usage = func() { fmt.Fprint(os.Stderr, standInUsage) }
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *keystorePath == "" {
+		fs.Usage()
+		return 2
+	}
+
+	passphrase, err := signer.ReadPassphrase(*passwordFile, "KEYSTORE_PASSWORD")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	s, err := signer.NewKeystoreSigner(*keystorePath, passphrase)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	server, err := signer.NewStandInServer(s)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	log.Printf("Stand-in signer for %s listening on http://%s", s.Address().Hex(), *listen)
+	if err := http.ListenAndServe(*listen, server); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}